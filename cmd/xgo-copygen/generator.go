@@ -0,0 +1,1275 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/glassonion1/xgo"
+	"github.com/glassonion1/xgo/internal/tag"
+)
+
+// generate type-checks the package in dir and returns the source of the copy functions for pairs.
+// The file named output is excluded from type-checking since it is going to be replaced.
+func generate(dir, output string, pairs []pair) ([]byte, error) {
+	fset := token.NewFileSet()
+	filter := func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}
+	pkgs, err := parser.ParseDir(fset, dir, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%s: want exactly one package, found %d", dir, len(pkgs))
+	}
+
+	var files []*ast.File
+	for _, p := range pkgs {
+		for _, f := range p.Files {
+			files = append(files, f)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return fset.File(files[i].Pos()).Name() < fset.File(files[j].Pos()).Name()
+	})
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(files[0].Name.Name, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		pkg:     pkg,
+		imports: map[string]bool{},
+		funcs:   map[string]string{},
+		names:   map[string]bool{},
+	}
+	for _, p := range pairs {
+		src, err := evalType(fset, pkg, p.src)
+		if err != nil {
+			return nil, err
+		}
+		dst, err := evalType(fset, pkg, p.dst)
+		if err != nil {
+			return nil, err
+		}
+		if !isStructOrSlice(src) {
+			return nil, fmt.Errorf("%s is not a struct or slice type", p.src)
+		}
+		if !isStructOrSlice(dst) {
+			return nil, fmt.Errorf("%s is not a struct or slice type", p.dst)
+		}
+		g.function(src, dst, "Copy"+exportedName(p.src)+"To"+exportedName(p.dst))
+	}
+
+	for len(g.queue) > 0 {
+		fn := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.generateFunc(fn); err != nil {
+			return nil, fmt.Errorf("%s: %w", fn.name, err)
+		}
+	}
+
+	return g.source()
+}
+
+func evalType(fset *token.FileSet, pkg *types.Package, expr string) (types.Type, error) {
+	tv, err := types.Eval(fset, pkg, token.NoPos, expr)
+	if err != nil {
+		return nil, err
+	}
+	if !tv.IsType() {
+		return nil, fmt.Errorf("%s is not a type", expr)
+	}
+	return tv.Type, nil
+}
+
+// copyFunc is a copy function to be generated
+type copyFunc struct {
+	name     string
+	src, dst types.Type
+}
+
+type generator struct {
+	pkg     *types.Package
+	imports map[string]bool
+	// funcs maps a type pair to the function name
+	funcs map[string]string
+	names map[string]bool
+	queue []copyFunc
+	buf   bytes.Buffer
+	seq   int
+	// wrap is the field name that prefixes the errors
+	wrap string
+	// epoch is the epoch unit option of the field tag, it is empty for UnixNano
+	epoch string
+}
+
+func (g *generator) p(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+	g.buf.WriteByte('\n')
+}
+
+func (g *generator) source() ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by xgo-copygen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", g.pkg.Name())
+
+	var paths []string
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if len(paths) > 0 {
+		fmt.Fprintf(&out, "import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&out, "%q\n", path)
+		}
+		fmt.Fprintf(&out, ")\n\n")
+	}
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated code: %w\n%s", err, out.Bytes())
+	}
+	return src, nil
+}
+
+// function returns the name of the copy function for the type pair, queueing it if it is new
+func (g *generator) function(src, dst types.Type, name string) string {
+	key := types.TypeString(src, nil) + ":" + types.TypeString(dst, nil)
+	if fn, ok := g.funcs[key]; ok {
+		return fn
+	}
+	base := name
+	for i := 2; g.names[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	g.names[name] = true
+	g.funcs[key] = name
+	g.queue = append(g.queue, copyFunc{name: name, src: src, dst: dst})
+	return name
+}
+
+func (g *generator) generateFunc(fn copyFunc) error {
+	g.seq = 0
+	if unicode.IsUpper(rune(fn.name[0])) {
+		g.p("// %s copies %s to %s like xgo.DeepCopy does.", fn.name, g.typeString(fn.src), g.typeString(fn.dst))
+	}
+	g.p("func %s(src %s, dst *%s) error {", fn.name, g.typeString(fn.src), g.typeString(fn.dst))
+
+	if _, ok := fn.src.Underlying().(*types.Slice); ok {
+		g.wrap, g.epoch = "", ""
+		if err := g.copySlice("src", fn.src, "(*dst)", fn.dst); err != nil {
+			return err
+		}
+		g.p("return nil")
+		g.p("}\n")
+		return nil
+	}
+
+	if _, ok := fn.dst.Underlying().(*types.Struct); !ok {
+		return fmt.Errorf("cannot copy %s to %s", g.typeString(fn.src), g.typeString(fn.dst))
+	}
+
+	// What to do if the deepcopy destination model has a tag
+	srcToDstTagMap := map[string]string{}
+	dstFields := map[string]visibleField{}
+	dstTags := map[string]tag.Tag{}
+	for _, f := range g.visibleFields(fn.dst) {
+		t := parseTag(f, fn.src)
+		if t.Name != "" && !t.Skip && !isPath(t.Name) {
+			srcToDstTagMap[t.Name] = f.v.Name()
+		}
+		dstFields[f.v.Name()] = f
+		dstTags[f.v.Name()] = t
+	}
+
+	matched := map[string]bool{}
+	for _, field := range g.visibleFields(fn.src) {
+		if !field.v.Exported() {
+			continue
+		}
+
+		srcTag := parseTag(field, fn.dst)
+		if srcTag.Skip {
+			continue
+		}
+		dstFieldName := field.v.Name()
+		if srcTag.Name != "" {
+			dstFieldName = srcTag.Name
+		}
+		if name, ok := srcToDstTagMap[field.v.Name()]; ok {
+			dstFieldName = name
+		}
+
+		dstField, ok := dstFields[dstFieldName]
+		if isPath(dstFieldName) {
+			dstField, ok = g.fieldByPath(fn.dst, dstFieldName)
+		}
+		// the promoted fields of the embedded struct are copied instead,
+		// unless the destination has a named field for it
+		if g.isEmbeddedStruct(field.v) && (!ok || dstField.v.Anonymous()) {
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		// Ignores private field
+		if !xgo.IsFirstUpper(dstFieldName) {
+			continue
+		}
+		var dstTag tag.Tag
+		if !isPath(dstFieldName) {
+			dstTag = dstTags[dstField.v.Name()]
+		}
+		if dstTag.Skip {
+			continue
+		}
+		// the path to a nested field matches the outer field
+		name, _, _ := strings.Cut(dstFieldName, ".")
+		matched[name] = true
+
+		if err := g.copyStructField(fn, field.v.Name(), field, dstField, srcTag, dstTag); err != nil {
+			return err
+		}
+	}
+
+	// the destination fields that the tag maps from the nested source fields like Address.City
+	for _, f := range g.visibleFields(fn.dst) {
+		t := dstTags[f.v.Name()]
+		if t.Skip || !isPath(t.Name) || !f.v.Exported() {
+			continue
+		}
+		srcField, ok := g.fieldByPath(fn.src, t.Name)
+		if !ok {
+			continue
+		}
+		matched[f.v.Name()] = true
+		if err := g.copyStructField(fn, t.Name, srcField, f, tag.Tag{}, t); err != nil {
+			return err
+		}
+	}
+
+	// the destination fields that the copier tag requires
+	for _, f := range g.visibleFields(fn.dst) {
+		if dstTags[f.v.Name()].Required && !matched[f.v.Name()] {
+			return fmt.Errorf("%s: no source field is copied to the field", f.v.Name())
+		}
+	}
+
+	g.p("return nil")
+	g.p("}\n")
+	return nil
+}
+
+// copyStructField writes the statements that copy a source field to a destination field of the struct types,
+// the name is the source field name or path
+func (g *generator) copyStructField(fn copyFunc, name string, field, dstField visibleField, srcTag, dstTag tag.Tag) error {
+	srcExpr, srcPtrs := fieldPath("src", fn.src, field.index)
+	dstExpr, dstPtrs := fieldPath("dst", fn.dst, dstField.index)
+	if !g.settable(fn.dst, dstField.index) {
+		return nil
+	}
+
+	if srcTag.Required || dstTag.Required {
+		cond := g.nonZero(srcExpr, field.v.Type())
+		if len(srcPtrs) > 0 {
+			cond = strings.Join(append(notNil(srcPtrs), cond), " && ")
+		}
+		g.imports["errors"] = true
+		g.p("if !(%s) {", cond)
+		g.p("return errors.New(%q)", name+": the required field has the zero value")
+		g.p("}")
+	}
+	// promoted through a nil pointer
+	if len(srcPtrs) > 0 {
+		g.p("if %s {", strings.Join(notNil(srcPtrs), " && "))
+	}
+	// the pointers on the way are allocated only when there is a value to copy
+	if len(dstPtrs) > 0 || srcTag.OmitEmpty || dstTag.OmitEmpty {
+		g.p("if %s {", g.nonZero(srcExpr, field.v.Type()))
+		for _, ptr := range dstPtrs {
+			g.p("if %s == nil {", ptr.expr)
+			g.p("%s = new(%s)", ptr.expr, g.typeString(ptr.elem))
+			g.p("}")
+		}
+	}
+
+	g.wrap = name
+	g.epoch = srcTag.Epoch
+	if g.epoch == "" {
+		g.epoch = dstTag.Epoch
+	}
+	st, dt := field.v.Type(), dstField.v.Type()
+	var err error
+	switch {
+	case (srcTag.Shallow || dstTag.Shallow) && types.AssignableTo(st, dt):
+		g.p("%s = %s", dstExpr, srcExpr)
+	case (srcTag.Deep || dstTag.Deep) && g.deepCopyable(st) && g.deepCopyable(dt):
+		err = g.copyDeep(srcExpr, dstExpr, st, dt)
+	default:
+		err = g.copyField(srcExpr, dstExpr, st, dt)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	if len(dstPtrs) > 0 || srcTag.OmitEmpty || dstTag.OmitEmpty {
+		g.p("}")
+	}
+	if len(srcPtrs) > 0 {
+		g.p("}")
+	}
+	return nil
+}
+
+// copyField writes the statements that copy a struct field the way DeepCopyWithCustomSetter does
+func (g *generator) copyField(src, dst string, st, dt types.Type) error {
+	if isInterface(st) || isInterface(dt) {
+		g.copyInterface(src, dst, st, dt)
+		return nil
+	}
+	if convertible(st, dt) {
+		g.p("%s = %s", dst, g.conversion(src, st, dt))
+		return nil
+	}
+
+	if sp, ok := st.(*types.Pointer); ok {
+		// a nil pointer is not copied
+		g.p("if %s != nil {", src)
+		defer g.p("}")
+
+		elem := sp.Elem()
+		if g.convertPtr("(*"+src+")", dst, elem, dt) {
+			return nil
+		}
+		if g.setTimeField(src, dst, st, dt) {
+			return nil
+		}
+		if ok, err := g.convertText(src, dst, st, dt); ok || err != nil {
+			return err
+		}
+		return g.instantiate(src, st, dst, dt)
+	}
+
+	if g.convertPtr(src, dst, st, dt) {
+		return nil
+	}
+	if g.setTimeField(src, dst, st, dt) {
+		return nil
+	}
+	if ok, err := g.convertText(src, dst, st, dt); ok || err != nil {
+		return err
+	}
+
+	// struct, slice, map
+	switch st.Underlying().(type) {
+	case *types.Struct:
+		return g.instantiate(src, st, dst, dt)
+	case *types.Slice, *types.Array:
+		return g.copySlice(src, st, dst, dt)
+	case *types.Map:
+		if _, ok := dt.Underlying().(*types.Map); !ok {
+			return nil
+		}
+		return g.copyMap(src, st, dst, dt)
+	}
+
+	// the other kinds are not copied
+	return nil
+}
+
+// visibleField is a field of a struct type including the promoted fields
+type visibleField struct {
+	v     *types.Var
+	tag   string
+	index []int
+}
+
+// visibleFields returns the fields of the struct type the way reflect.VisibleFields does
+func (g *generator) visibleFields(t types.Type) []visibleField {
+	var (
+		names []string
+		seen  = map[string]bool{}
+		walk  func(t types.Type, visiting map[string]bool)
+	)
+	walk = func(t types.Type, visiting map[string]bool) {
+		key := types.TypeString(t, nil)
+		if visiting[key] {
+			return
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+
+		s, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return
+		}
+		for i := 0; i < s.NumFields(); i++ {
+			f := s.Field(i)
+			if !seen[f.Name()] {
+				seen[f.Name()] = true
+				names = append(names, f.Name())
+			}
+			if f.Anonymous() {
+				walk(derefType(f.Type()), visiting)
+			}
+		}
+	}
+	walk(t, map[string]bool{})
+
+	var fields []visibleField
+	for _, name := range names {
+		// an ambiguous name resolves to nothing
+		obj, index, _ := types.LookupFieldOrMethod(t, false, g.pkg, name)
+		v, ok := obj.(*types.Var)
+		if !ok || !v.IsField() {
+			continue
+		}
+		fields = append(fields, visibleField{v: v, tag: fieldTag(t, index), index: index})
+	}
+	// the order of reflect.VisibleFields is the depth-first order of the index
+	sort.SliceStable(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return fields
+}
+
+// isEmbeddedStruct determines whether the field is an embedded struct that has exported fields to promote
+func (g *generator) isEmbeddedStruct(f *types.Var) bool {
+	if !f.Anonymous() {
+		return false
+	}
+	t := derefType(f.Type())
+	if _, ok := t.Underlying().(*types.Struct); !ok {
+		return false
+	}
+	for _, vf := range g.visibleFields(t) {
+		if vf.v.Exported() && !vf.v.Anonymous() {
+			return true
+		}
+	}
+	return false
+}
+
+// settable determines whether the pointers on the way to the field can be allocated
+func (g *generator) settable(t types.Type, index []int) bool {
+	for _, x := range index[:len(index)-1] {
+		f := derefType(t).Underlying().(*types.Struct).Field(x)
+		if _, ok := f.Type().(*types.Pointer); ok && !f.Exported() && f.Pkg() != g.pkg {
+			return false
+		}
+		t = f.Type()
+	}
+	return true
+}
+
+// isPath determines whether the name of the copier tag is a path to a nested field like Address.City
+func isPath(name string) bool {
+	return strings.Contains(name, ".")
+}
+
+// fieldByPath returns the exported nested field of the dotted path the way DeepCopy resolves it
+func (g *generator) fieldByPath(t types.Type, name string) (visibleField, bool) {
+	var (
+		v     *types.Var
+		index []int
+		cur   = t
+	)
+	for _, n := range strings.Split(name, ".") {
+		cur = derefType(cur)
+		if _, ok := cur.Underlying().(*types.Struct); !ok {
+			return visibleField{}, false
+		}
+		obj, idx, _ := types.LookupFieldOrMethod(cur, false, g.pkg, n)
+		f, ok := obj.(*types.Var)
+		if !ok || !f.IsField() || !f.Exported() {
+			return visibleField{}, false
+		}
+		v, index, cur = f, append(index, idx...), f.Type()
+	}
+	return visibleField{v: v, tag: fieldTag(t, index), index: index}, true
+}
+
+// embeddedPtr is a pointer on the way to a promoted or nested field
+type embeddedPtr struct {
+	expr string
+	elem types.Type
+}
+
+// fieldPath returns the selector of the field of the index and the pointers on the way
+func fieldPath(root string, t types.Type, index []int) (string, []embeddedPtr) {
+	expr := root
+	var ptrs []embeddedPtr
+	for i, x := range index {
+		f := derefType(t).Underlying().(*types.Struct).Field(x)
+		expr += "." + f.Name()
+		if p, ok := f.Type().(*types.Pointer); ok && i < len(index)-1 {
+			ptrs = append(ptrs, embeddedPtr{expr: expr, elem: p.Elem()})
+		}
+		t = f.Type()
+	}
+	return expr, ptrs
+}
+
+// parseTag parses the copier tag of the field for the other struct type the way DeepCopy does,
+// the package qualifier is the last element of the import path and not the package name to match the runtime
+func parseTag(f visibleField, other types.Type) tag.Tag {
+	s, ok := reflect.StructTag(f.tag).Lookup(tag.Key)
+	if !ok {
+		return tag.Tag{}
+	}
+	n, ok := other.(*types.Named)
+	if !ok || n.Obj().Pkg() == nil {
+		return tag.Parse(s)
+	}
+	name := n.Obj().Name()
+	pkg := path.Base(n.Obj().Pkg().Path())
+	return tag.Parse(s, pkg+"."+name, name, pkg)
+}
+
+// deepCopyable determines whether the deep option copies the type instead of assigning it
+func (g *generator) deepCopyable(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Array, *types.Map:
+		return true
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if u.Field(i).Exported() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// copyDeep writes the statements that copy a field of the deep option by the nested copy
+func (g *generator) copyDeep(src, dst string, st, dt types.Type) error {
+	if sp, ok := st.(*types.Pointer); ok {
+		g.p("if %s != nil {", src)
+		defer g.p("}")
+		switch sp.Elem().Underlying().(type) {
+		case *types.Struct, *types.Slice, *types.Array, *types.Map:
+			return g.instantiate(src, st, dst, dt)
+		}
+		if g.convertPtr("(*"+src+")", dst, sp.Elem(), dt) {
+			return nil
+		}
+		return g.instantiate(src, st, dst, dt)
+	}
+
+	switch st.Underlying().(type) {
+	case *types.Struct:
+		return g.instantiate(src, st, dst, dt)
+	case *types.Slice, *types.Array:
+		return g.copySlice(src, st, dst, dt)
+	case *types.Map:
+		return g.copyMap(src, st, dst, dt)
+	}
+	return g.copyField(src, dst, st, dt)
+}
+
+// fieldTag returns the tag of the field of the index
+func fieldTag(t types.Type, index []int) string {
+	var tag string
+	for _, x := range index {
+		s := derefType(t).Underlying().(*types.Struct)
+		tag = s.Tag(x)
+		t = s.Field(x).Type()
+	}
+	return tag
+}
+
+func notNil(ptrs []embeddedPtr) []string {
+	conds := make([]string, len(ptrs))
+	for i, ptr := range ptrs {
+		conds[i] = ptr.expr + " != nil"
+	}
+	return conds
+}
+
+// nonZero returns the condition that the value is not the zero value like reflect.Value.IsZero
+func (g *generator) nonZero(expr string, t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return expr + " != nil"
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return expr + ` != ""`
+		case u.Info()&types.IsBoolean != 0:
+			return expr
+		}
+		return expr + " != 0"
+	}
+	if types.Comparable(t) {
+		return fmt.Sprintf("%s != (%s{})", expr, g.typeString(t))
+	}
+	g.imports["reflect"] = true
+	return fmt.Sprintf("!reflect.ValueOf(%s).IsZero()", expr)
+}
+
+func derefType(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
+
+// copyElem writes the statements that copy a slice element the way copySlice does
+func (g *generator) copyElem(src, dst string, st, dt types.Type) error {
+	if isInterface(st) || isInterface(dt) {
+		g.copyInterface(src, dst, st, dt)
+		return nil
+	}
+	if convertible(st, dt) {
+		g.p("%s = %s", dst, g.conversion(src, st, dt))
+		return nil
+	}
+
+	if sp, ok := st.(*types.Pointer); ok {
+		g.p("if %s != nil {", src)
+		defer g.p("}")
+
+		if g.convertPtr("(*"+src+")", dst, sp.Elem(), dt) {
+			return nil
+		}
+		if ok, err := g.convertText(src, dst, st, dt); ok || err != nil {
+			return err
+		}
+		return g.instantiate(src, st, dst, dt)
+	}
+
+	if g.convertPtr(src, dst, st, dt) {
+		return nil
+	}
+	if ok, err := g.convertText(src, dst, st, dt); ok || err != nil {
+		return err
+	}
+	return g.instantiate(src, st, dst, dt)
+}
+
+// copyInterface writes the call of xgo.DeepCopy that copies the dynamic value of the interface the way copyInterface does,
+// the values are wrapped in structs since DeepCopy dereferences a top-level pointer
+func (g *generator) copyInterface(src, dst string, st, dt types.Type) {
+	g.imports["github.com/glassonion1/xgo"] = true
+	// a nil interface is not copied
+	if isInterface(st) {
+		g.p("if %s != nil {", src)
+		defer g.p("}")
+	}
+	v := g.tmp("v")
+	g.p("var %s struct{ V %s }", v, g.typeString(dt))
+	g.p("if err := xgo.DeepCopy(struct{ V %s }{V: %s}, &%s); err != nil {", g.typeString(st), src, v)
+	g.returnErr()
+	g.p("}")
+	g.p("%s = %s.V", dst, v)
+}
+
+// convertPtr writes a conversion from a value to a value or a pointer, it reports whether the types are convertible
+func (g *generator) convertPtr(src, dst string, st, dt types.Type) bool {
+	if convertible(st, dt) {
+		g.p("%s = %s", dst, g.conversion(src, st, dt))
+		return true
+	}
+	if dp, ok := dt.(*types.Pointer); ok && convertible(st, dp.Elem()) {
+		v := g.tmp("v")
+		g.p("%s := %s", v, g.conversion(src, st, dp.Elem()))
+		g.p("%s = &%s", dst, v)
+		return true
+	}
+	return false
+}
+
+// instantiate writes the statements that copy src to a new value of dt
+func (g *generator) instantiate(src string, st types.Type, dst string, dt types.Type) error {
+	target := dt
+	dp, isPtr := dt.(*types.Pointer)
+	if isPtr {
+		target = dp.Elem()
+	}
+
+	v := g.tmp("v")
+	g.p("var %s %s", v, g.typeString(target))
+	if err := g.deepCopy(src, st, v, target); err != nil {
+		return err
+	}
+	if isPtr {
+		g.p("%s = &%s", dst, v)
+		return nil
+	}
+	g.p("%s = %s", dst, v)
+	return nil
+}
+
+// deepCopy writes the statements that copy src to the variable dst the way DeepCopy does
+func (g *generator) deepCopy(src string, st types.Type, dst string, dt types.Type) error {
+	if sp, ok := st.(*types.Pointer); ok {
+		src, st = "(*"+src+")", sp.Elem()
+	}
+
+	switch st.Underlying().(type) {
+	case *types.Slice, *types.Array:
+		return g.copySlice(src, st, dst, dt)
+	case *types.Map:
+		return g.copyMap(src, st, dst, dt)
+	case *types.Struct:
+		if _, ok := dt.Underlying().(*types.Struct); !ok {
+			return fmt.Errorf("cannot copy %s to %s", g.typeString(st), g.typeString(dt))
+		}
+		fn := g.function(st, dt, "copy"+exportedName(g.typeString(st))+"To"+exportedName(g.typeString(dt)))
+		g.p("if err := %s(%s, &%s); err != nil {", fn, src, dst)
+		g.returnErr()
+		g.p("}")
+		return nil
+	}
+	return fmt.Errorf("cannot copy %s to %s", g.typeString(st), g.typeString(dt))
+}
+
+// copySlice writes the statements that copy a slice or an array the way copySlice does
+func (g *generator) copySlice(src string, st types.Type, dst string, dt types.Type) error {
+	se, ok := listElem(st)
+	if !ok {
+		return fmt.Errorf("cannot copy %s to %s", g.typeString(st), g.typeString(dt))
+	}
+	de, ok := listElem(dt)
+	if !ok {
+		return fmt.Errorf("cannot copy %s to %s", g.typeString(st), g.typeString(dt))
+	}
+
+	_, srcSlice := st.Underlying().(*types.Slice)
+	if srcSlice {
+		g.p("if %s != nil {", src)
+		defer g.p("}")
+	}
+
+	i := g.tmp("i")
+	if _, ok := dt.Underlying().(*types.Array); ok {
+		// the elements that do not fit in the array are truncated
+		g.p("%s = %s{}", dst, g.typeString(dt))
+		g.p("for %s := 0; %s < len(%s) && %s < len(%s); %s++ {", i, i, src, i, dst, i)
+	} else {
+		capacity := "len(" + src + ")"
+		if srcSlice {
+			capacity = "cap(" + src + ")"
+		}
+		g.p("%s = make([]%s, len(%s), %s)", dst, g.typeString(de), src, capacity)
+		g.p("for %s := range %s {", i, src)
+	}
+	if err := g.copyElem(src+"["+i+"]", dst+"["+i+"]", se, de); err != nil {
+		return err
+	}
+	g.p("}")
+	return nil
+}
+
+// convertible reports whether st is converted to dt the way DeepCopy converts, a slice is not converted to an array
+func convertible(st, dt types.Type) bool {
+	// an integer that has the text methods like time.Duration is formatted with them
+	if isKind(st, types.IsInteger) && isKind(dt, types.IsString) && hasTextMethod(st) {
+		return false
+	}
+	// the dynamic values of the interface elements are copied one by one
+	if e, ok := listElem(st); ok && isInterface(e) {
+		return false
+	}
+	if m, ok := st.Underlying().(*types.Map); ok && isInterface(m.Elem()) {
+		return false
+	}
+	if _, ok := st.Underlying().(*types.Slice); ok {
+		t := dt
+		if p, ok := dt.Underlying().(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		if _, ok := t.Underlying().(*types.Array); ok {
+			return false
+		}
+	}
+	return types.ConvertibleTo(st, dt)
+}
+
+// listElem returns the element type of a slice or an array
+func listElem(t types.Type) (types.Type, bool) {
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return u.Elem(), true
+	case *types.Array:
+		return u.Elem(), true
+	}
+	return nil, false
+}
+
+// copyMap writes the statements that copy a map the way copyMap does
+func (g *generator) copyMap(src string, st types.Type, dst string, dt types.Type) error {
+	dm, ok := dt.Underlying().(*types.Map)
+	if !ok {
+		return fmt.Errorf("cannot copy %s to %s", g.typeString(st), g.typeString(dt))
+	}
+	sm := st.Underlying().(*types.Map)
+
+	g.p("if %s != nil {", src)
+	g.p("%s = make(%s, len(%s))", dst, g.typeString(dt), src)
+	k, v := g.tmp("k"), g.tmp("v")
+	g.p("for %s, %s := range %s {", k, v, src)
+	dk, dv := g.tmp("k"), g.tmp("v")
+	g.p("var %s %s", dk, g.typeString(dm.Key()))
+	if err := g.copyElem(k, dk, sm.Key(), dm.Key()); err != nil {
+		return err
+	}
+	g.p("var %s %s", dv, g.typeString(dm.Elem()))
+	if err := g.copyElem(v, dv, sm.Elem(), dm.Elem()); err != nil {
+		return err
+	}
+	g.p("%s[%s] = %s", dst, dk, dv)
+	g.p("}")
+	g.p("}")
+	return nil
+}
+
+// setTimeField writes the time conversions of setTimeField, it reports whether the types are handled
+func (g *generator) setTimeField(src, dst string, st, dt types.Type) bool {
+	s, srcPtr := indirect(st)
+	d, dstPtr := indirect(dt)
+	if srcPtr {
+		src = "(*" + src + ")"
+	}
+
+	var expr string
+	switch {
+	case isTime(s) && isEpoch(d):
+		expr = g.epochNumber(src, d)
+		if dstPtr && token.IsIdentifier(expr) {
+			g.setValue(dst, expr, true)
+			return true
+		}
+	case isTime(s) && isBasic(d, types.String):
+		g.imports["time"] = true
+		expr = src + ".Format(time.RFC3339Nano)"
+	case isEpoch(s) && isTime(d):
+		expr = g.epochTime(src, s)
+	case isBasic(s, types.String) && isTime(d):
+		// an empty string is not parsed
+		g.imports["time"] = true
+		v := g.tmp("v")
+		g.p("if %s != \"\" {", src)
+		g.p("%s, err := time.Parse(time.RFC3339Nano, %s)", v, src)
+		g.p("if err != nil {")
+		g.returnErr()
+		g.p("}")
+		g.setValue(dst, v, dstPtr)
+		g.p("}")
+		return true
+	default:
+		return false
+	}
+
+	if !dstPtr {
+		g.p("%s = %s", dst, expr)
+		return true
+	}
+	v := g.tmp("v")
+	g.p("%s := %s", v, expr)
+	g.setValue(dst, v, true)
+	return true
+}
+
+// epochRanges are the conditions that an int64 epoch does not fit the integer kind, int64 has no condition
+var epochRanges = map[types.BasicKind]string{
+	types.Int:     "%[1]s < math.MinInt || %[1]s > math.MaxInt",
+	types.Int8:    "%[1]s < math.MinInt8 || %[1]s > math.MaxInt8",
+	types.Int16:   "%[1]s < math.MinInt16 || %[1]s > math.MaxInt16",
+	types.Int32:   "%[1]s < math.MinInt32 || %[1]s > math.MaxInt32",
+	types.Uint:    "%[1]s < 0 || uint64(%[1]s) > math.MaxUint",
+	types.Uint8:   "%[1]s < 0 || %[1]s > math.MaxUint8",
+	types.Uint16:  "%[1]s < 0 || %[1]s > math.MaxUint16",
+	types.Uint32:  "%[1]s < 0 || %[1]s > math.MaxUint32",
+	types.Uint64:  "%[1]s < 0",
+	types.Uintptr: "%[1]s < 0 || uint64(%[1]s) > math.MaxUint",
+}
+
+// epochNanos returns the float nanoseconds of the epoch unit, it is empty for UnixNano
+func (g *generator) epochNanos() string {
+	switch g.epoch {
+	case "unix":
+		return "float64(time.Second)"
+	case "unixmilli":
+		return "float64(time.Millisecond)"
+	case "unixmicro":
+		return "float64(time.Microsecond)"
+	}
+	return ""
+}
+
+// epochNumber writes the statements that turn the time to the number of the epoch unit the way setEpochNumber does,
+// the epoch that does not fit the integer type returns an error. It returns the expression of the number.
+func (g *generator) epochNumber(src string, d types.Type) string {
+	g.imports["time"] = true
+	t := g.tmp("t")
+	g.p("%s := %s", t, src)
+	if isKind(d, types.IsFloat) {
+		// a float has the range of the epoch, not the precision
+		expr := fmt.Sprintf("float64(%[1]s.Unix())*float64(time.Second) + float64(%[1]s.Nanosecond())", t)
+		if n := g.epochNanos(); n != "" {
+			expr = fmt.Sprintf("float64(%[1]s.Unix())*float64(time.Second)/%[2]s + float64(%[1]s.Nanosecond())/%[2]s", t, n)
+		}
+		return g.conversion(expr, types.Typ[types.Float64], d)
+	}
+
+	v := g.tmp("v")
+	switch g.epoch {
+	case "unix":
+		g.p("%s := %s.Unix()", v, t)
+	case "unixmilli":
+		g.p("%s := %s.UnixMilli()", v, t)
+	case "unixmicro":
+		g.p("%s := %s.UnixMicro()", v, t)
+	default:
+		g.p("%s := %s.UnixNano()", v, t)
+	}
+	// the epoch is checked since an integer smaller than int64 wraps it
+	if cond, ok := epochRanges[d.Underlying().(*types.Basic).Kind()]; ok {
+		if strings.Contains(cond, "math.") {
+			g.imports["math"] = true
+		}
+		g.imports["fmt"] = true
+		g.p("if "+cond+" {", v)
+		g.p("err := fmt.Errorf(%q, %s)", "%d: the number does not fit "+g.typeString(d), v)
+		g.returnErr()
+		g.p("}")
+	}
+	return g.conversion(v, types.Typ[types.Int64], d)
+}
+
+// epochTime writes the statements that turn the number of the epoch unit to the time the way toTime does,
+// it returns the expression of the time
+func (g *generator) epochTime(src string, s types.Type) string {
+	g.imports["time"] = true
+	if isKind(s, types.IsFloat) {
+		g.imports["math"] = true
+		expr := g.conversion(src, s, types.Typ[types.Float64]) + "/float64(time.Second)"
+		if n := g.epochNanos(); n != "" {
+			expr = g.conversion(src, s, types.Typ[types.Float64]) + "*" + n + "/float64(time.Second)"
+		}
+		sec, frac := g.tmp("sec"), g.tmp("frac")
+		g.p("%s, %s := math.Modf(%s)", sec, frac, expr)
+		return fmt.Sprintf("time.Unix(int64(%s), int64(math.Round(%s*float64(time.Second))))", sec, frac)
+	}
+
+	n := g.conversion(src, s, types.Typ[types.Int64])
+	switch g.epoch {
+	case "unix":
+		return "time.Unix(" + n + ", 0)"
+	case "unixmilli":
+		return "time.UnixMilli(" + n + ")"
+	case "unixmicro":
+		return "time.UnixMicro(" + n + ")"
+	}
+	return "time.Unix(0, " + n + ")"
+}
+
+// isEpoch determines whether the type is a number that setTimeField copies from and to time.Time,
+// time.Duration is not a time
+func isEpoch(t types.Type) bool {
+	return isKind(t, types.IsInteger|types.IsFloat) && !isDuration(t)
+}
+
+// convertText writes the statements that parse a string to a number, a bool, time.Duration or an encoding.TextUnmarshaler,
+// and format them to a string the way DeepCopy does. It reports whether the pair is handled.
+func (g *generator) convertText(src, dst string, st, dt types.Type) (bool, error) {
+	s, srcPtr := indirect(st)
+	d, dstPtr := indirect(dt)
+	if !isTextPair(s, d) {
+		return false, nil
+	}
+	if srcPtr {
+		src = "(*" + src + ")"
+	}
+	stringType := types.Typ[types.String]
+
+	if isKind(s, types.IsString) {
+		// an empty string is not parsed
+		g.p("if %s != \"\" {", src)
+		defer g.p("}")
+		text := g.conversion(src, s, stringType)
+
+		v := g.tmp("v")
+		if hasMethod(d, "UnmarshalText", "([]byte) (error)") {
+			g.p("var %s %s", v, g.typeString(d))
+			g.p("if err := %s.UnmarshalText([]byte(%s)); err != nil {", v, text)
+			g.returnErr()
+			g.p("}")
+			g.setValue(dst, v, dstPtr)
+			return true, nil
+		}
+
+		g.imports["strconv"] = true
+		var parsed types.Type
+		switch {
+		case isDuration(d):
+			g.imports["time"] = true
+			g.p("%s, err := time.ParseDuration(%s)", v, text)
+			parsed = d
+		case isKind(d, types.IsBoolean):
+			g.p("%s, err := strconv.ParseBool(%s)", v, text)
+			parsed = types.Typ[types.Bool]
+		case isKind(d, types.IsFloat):
+			g.p("%s, err := strconv.ParseFloat(%s, %d)", v, text, bitSize(d))
+			parsed = types.Typ[types.Float64]
+		case isKind(d, types.IsUnsigned):
+			g.p("%s, err := strconv.ParseUint(%s, 10, %d)", v, text, bitSize(d))
+			parsed = types.Typ[types.Uint64]
+		default:
+			g.p("%s, err := strconv.ParseInt(%s, 10, %d)", v, text, bitSize(d))
+			parsed = types.Typ[types.Int64]
+		}
+		g.p("if err != nil {")
+		g.returnErr()
+		g.p("}")
+		if !dstPtr {
+			g.p("%s = %s", dst, g.conversion(v, parsed, d))
+			return true, nil
+		}
+		p := g.tmp("v")
+		g.p("%s := %s", p, g.conversion(v, parsed, d))
+		g.setValue(dst, p, true)
+		return true, nil
+	}
+
+	var expr string
+	switch {
+	case hasMethod(s, "MarshalText", "() ([]byte, error)"):
+		// the copy is addressable for the pointer receiver
+		v, b := g.tmp("v"), g.tmp("b")
+		g.p("%s := %s", v, src)
+		g.p("%s, err := %s.MarshalText()", b, v)
+		g.p("if err != nil {")
+		g.returnErr()
+		g.p("}")
+		expr = "string(" + b + ")"
+	case hasMethod(s, "String", "() (string)"):
+		v := g.tmp("v")
+		g.p("%s := %s", v, src)
+		expr = v + ".String()"
+	case isKind(s, types.IsBoolean):
+		g.imports["strconv"] = true
+		expr = "strconv.FormatBool(" + g.conversion(src, s, types.Typ[types.Bool]) + ")"
+	default:
+		g.imports["strconv"] = true
+		expr = fmt.Sprintf("strconv.FormatFloat(%s, 'g', -1, %d)", g.conversion(src, s, types.Typ[types.Float64]), bitSize(s))
+	}
+	if !dstPtr {
+		g.p("%s = %s", dst, g.conversion(expr, stringType, d))
+		return true, nil
+	}
+	v := g.tmp("v")
+	g.p("%s := %s", v, g.conversion(expr, stringType, d))
+	g.setValue(dst, v, true)
+	return true, nil
+}
+
+// isTextPair reports whether DeepCopy parses or formats s to d as a string, the types are not pointers
+func isTextPair(s, d types.Type) bool {
+	switch {
+	case isTime(s) || isTime(d):
+		return false
+	case isKind(s, types.IsString) && isKind(d, types.IsString):
+		return false
+	case isKind(d, types.IsString):
+		// String formats only the bools and the numbers like enums the way DeepCopy does
+		return hasMethod(s, "MarshalText", "() ([]byte, error)") || isKind(s, types.IsBoolean|types.IsFloat) || isKind(s, types.IsInteger) && hasTextMethod(s)
+	case isKind(s, types.IsString):
+		return hasMethod(d, "UnmarshalText", "([]byte) (error)") || isKind(d, types.IsBoolean|types.IsInteger|types.IsFloat)
+	}
+	return false
+}
+
+// hasTextMethod determines whether the type formats itself to a string with MarshalText or String
+func hasTextMethod(t types.Type) bool {
+	return hasMethod(t, "MarshalText", "() ([]byte, error)") || hasMethod(t, "String", "() (string)")
+}
+
+// hasMethod determines whether the type or the pointer to it has the method of the signature like "() (string)"
+func hasMethod(t types.Type, name, sig string) bool {
+	if _, ok := t.(*types.Pointer); ok {
+		return false
+	}
+	sel := types.NewMethodSet(types.NewPointer(t)).Lookup(nil, name)
+	if sel == nil {
+		return false
+	}
+	s, ok := sel.Type().(*types.Signature)
+	return ok && tupleString(s.Params())+" "+tupleString(s.Results()) == sig
+}
+
+// tupleString formats the types of the parameters or the results like ([]byte, error)
+func tupleString(t *types.Tuple) string {
+	list := make([]string, t.Len())
+	for i := range list {
+		list[i] = types.TypeString(t.At(i).Type(), nil)
+	}
+	return "(" + strings.Join(list, ", ") + ")"
+}
+
+// bitSize returns the bit size of the number type for strconv, 0 for int and uint
+func bitSize(t types.Type) int {
+	switch t.Underlying().(*types.Basic).Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Int, types.Uint:
+		return 0
+	}
+	return 64
+}
+
+// isDuration reports whether t is time.Duration
+func isDuration(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := n.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Duration"
+}
+
+func (g *generator) setValue(dst, v string, ptr bool) {
+	if ptr {
+		g.p("%s = &%s", dst, v)
+		return
+	}
+	g.p("%s = %s", dst, v)
+}
+
+func (g *generator) returnErr() {
+	if g.wrap == "" {
+		g.p("return err")
+		return
+	}
+	g.imports["fmt"] = true
+	g.p("return fmt.Errorf(%q, err)", g.wrap+": %v")
+}
+
+// conversion returns the expression that converts x of st to dt
+func (g *generator) conversion(x string, st, dt types.Type) string {
+	if types.Identical(st, dt) {
+		return x
+	}
+	// an integer is formatted to a string as a decimal number
+	if isKind(dt, types.IsString) && isKind(st, types.IsInteger) {
+		g.imports["strconv"] = true
+		if isKind(st, types.IsUnsigned) {
+			x = "strconv.FormatUint(uint64(" + x + "), 10)"
+		} else {
+			x = "strconv.FormatInt(int64(" + x + "), 10)"
+		}
+		if types.Identical(dt, types.Typ[types.String]) {
+			return x
+		}
+	}
+	t := g.typeString(dt)
+	if strings.HasPrefix(t, "*") || strings.HasPrefix(t, "func") || strings.HasPrefix(t, "<-") {
+		t = "(" + t + ")"
+	}
+	return t + "(" + x + ")"
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+		g.imports[p.Path()] = true
+		return p.Name()
+	})
+}
+
+func (g *generator) tmp(prefix string) string {
+	g.seq++
+	return fmt.Sprintf("%s%d", prefix, g.seq)
+}
+
+func indirect(t types.Type) (types.Type, bool) {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem(), true
+	}
+	return t, false
+}
+
+func isStructOrSlice(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Struct, *types.Slice:
+		return true
+	}
+	return false
+}
+
+// isTime reports whether t is time.Time
+// isInterface determines whether the type is an interface, its dynamic value is copied at runtime
+func isInterface(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Interface)
+	return ok
+}
+
+func isTime(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := n.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time"
+}
+
+// isBasic reports whether t is exactly the predeclared type of kind
+func isBasic(t types.Type, kind types.BasicKind) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Kind() == kind
+}
+
+func isKind(t types.Type, info types.BasicInfo) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&info != 0
+}
+
+// exportedName turns a type expression like []*pkg.Pair[int] into an identifier like SlicePkgPairInt
+func exportedName(expr string) string {
+	var b strings.Builder
+	expr = strings.ReplaceAll(expr, "[]", "Slice ")
+	upper := true
+	for _, r := range expr {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,294 @@
+package fixture_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo"
+	"github.com/glassonion1/xgo/cmd/xgo-copygen/internal/fixture"
+)
+
+// compare checks that the generated function produces the same result as xgo.DeepCopy
+func compare[S, D any](t *testing.T, name string, src S, copyFunc func(S, *D) error) {
+	t.Helper()
+
+	t.Run(name, func(t *testing.T) {
+		t.Parallel()
+		want := new(D)
+		if err := xgo.DeepCopy(src, want); err != nil {
+			t.Fatalf("testing %s: should not be error for %#v but: %v", name, src, err)
+		}
+		got := new(D)
+		if err := copyFunc(src, got); err != nil {
+			t.Fatalf("testing %s: should not be error for %#v but: %v", name, src, err)
+		}
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(fixture.PrivateField{})); diff != "" {
+			t.Errorf("testing %s mismatch (-deepcopy +generated):\n%s\n", name, diff)
+		}
+	})
+}
+
+func TestGenerated(t *testing.T) {
+	now := time.Now()
+	const format = time.RFC3339Nano
+
+	modelA := fixture.ModelA{
+		Field:     "foo",
+		CreatedAt: now,
+		UpdatedAt: &now,
+		Model:     fixture.ModelC{Field: "bar"},
+	}
+	compare(t, "struct copy", fixture.FromPtrTestdata{
+		StructToStruct: modelA,
+		StructToPtr:    modelA,
+		PtrToStruct:    &modelA,
+		PtrToPtr:       &modelA,
+	}, fixture.CopyFromPtrTestdataToToPtrTestdata)
+	compare(t, "struct copy:nil pointer", fixture.FromPtrTestdata{}, fixture.CopyFromPtrTestdataToToPtrTestdata)
+	compare(t, "struct copy:field not found", fixture.FromPtrTestdata{
+		StructToStruct: fixture.ModelA{Field: "foo"},
+	}, fixture.CopyFromPtrTestdataToModelA)
+
+	compare(t, "int64 to int32 OK", fixture.Int64Field{ID: 2100000000}, fixture.CopyInt64FieldToInt32Field)
+	compare(t, "int64 to int32 overflow", fixture.Int64Field{ID: 2200000000}, fixture.CopyInt64FieldToInt32Field)
+	compare(t, "int32 to int64 OK", fixture.Int32Field{ID: 2100000000}, fixture.CopyInt32FieldToInt64Field)
+
+	compare(t, "time.Time to time.Time", fixture.TimeModelA{
+		CreatedAt: &now,
+		UpdatedAt: now,
+	}, fixture.CopyTimeModelAToTimeModelB)
+	timeModel := fixture.TimeModelC{
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		DeletedAt:  &now,
+		ReplacedAt: &now,
+	}
+	compare(t, "time.Time to int64", timeModel, fixture.CopyTimeModelCToTimeModelD)
+	compare(t, "time.Time to string", timeModel, fixture.CopyTimeModelCToTimeModelE)
+	compare(t, "int64 to time.Time", fixture.TimeModelD{
+		CreatedAt:  now.UnixNano(),
+		UpdatedAt:  xgo.ToPtr(now.UnixNano()),
+		DeletedAt:  now.UnixNano(),
+		ReplacedAt: xgo.ToPtr(now.UnixNano()),
+	}, fixture.CopyTimeModelDToTimeModelC)
+	compare(t, "string to time.Time", fixture.TimeModelE{
+		CreatedAt:  now.Format(format),
+		UpdatedAt:  xgo.ToPtr(now.Format(format)),
+		DeletedAt:  now.Format(format),
+		ReplacedAt: xgo.ToPtr(now.Format(format)),
+	}, fixture.CopyTimeModelEToTimeModelC)
+	compare(t, "string to time.Time:empty string", fixture.TimeModelE{
+		UpdatedAt: xgo.ToPtr(""),
+	}, fixture.CopyTimeModelEToTimeModelC)
+	t.Run("string to time.Time:invalid format", func(t *testing.T) {
+		t.Parallel()
+		for _, src := range []fixture.TimeModelE{{CreatedAt: "invalid"}, {UpdatedAt: xgo.ToPtr("invalid")}} {
+			if err := xgo.DeepCopy(src, &fixture.TimeModelC{}); err == nil {
+				t.Errorf("DeepCopy should be error for %#v but not", src)
+			}
+			if err := fixture.CopyTimeModelEToTimeModelC(src, &fixture.TimeModelC{}); err == nil {
+				t.Errorf("the generated function should be error for %#v but not", src)
+			}
+		}
+	})
+
+	compare(t, "private field value", fixture.PrivateField{ID: "id"}, fixture.CopyPrivateFieldToPrivateField)
+
+	example := fixture.Example1{
+		ID:         "id1",
+		Name:       "hoge1",
+		State:      100,
+		Tests:      []string{"test1", "test2"},
+		StructPtrs: []*fixture.Model1{{Foo: "foo1", Bar: 100}, {Foo: "foo2", Bar: 200}},
+		Structs:    []fixture.Model1{{Foo: "foo1", Bar: 1000}, {Foo: "foo2", Bar: 2000}},
+	}
+	compare(t, "slice value", example, fixture.CopyExample1ToExample2)
+	compare(t, "slice of pointers", []*fixture.Example1{&example, {ID: "id2"}, nil}, fixture.CopySliceExample1ToSliceExample2)
+	compare(t, "ptr slice", fixture.PtrSlice1{
+		StructToStruct: []fixture.Foo{"test1", "test2"},
+		StructToPtr:    []fixture.Foo{"test1", "test2"},
+		PtrToStruct:    []*fixture.Foo{xgo.ToPtr(fixture.Foo("test1")), nil},
+		PtrToPtr:       []*fixture.Foo{xgo.ToPtr(fixture.Foo("test1")), nil},
+	}, fixture.CopyPtrSlice1ToPtrSlice2)
+	compare(t, "ptr slice:nil or zero value", fixture.PtrSlice1{}, fixture.CopyPtrSlice1ToPtrSlice2)
+
+	compare(t, "int to int", fixture.Field1[int]{
+		ValToVal: 1000, ValToPtr: 2000, PtrToVal: xgo.ToPtr(3000), PtrToPtr: xgo.ToPtr(4000),
+	}, fixture.CopyField1IntToField2Int)
+	compare(t, "int32 to int64", fixture.Field1[int32]{
+		ValToVal: 1000, ValToPtr: 2000, PtrToVal: xgo.ToPtr(int32(3000)), PtrToPtr: xgo.ToPtr(int32(4000)),
+	}, fixture.CopyField1Int32ToField2Int64)
+	compare(t, "int64 to int32", fixture.Field1[int64]{
+		ValToVal: 1000, ValToPtr: 2000, PtrToVal: xgo.ToPtr(int64(3000)), PtrToPtr: xgo.ToPtr(int64(4000)),
+	}, fixture.CopyField1Int64ToField2Int32)
+	compare(t, "float32 to float64", fixture.Field1[float32]{
+		ValToVal: 10.625, ValToPtr: 10.625, PtrToVal: xgo.ToPtr(float32(10.625)), PtrToPtr: xgo.ToPtr(float32(10.625)),
+	}, fixture.CopyField1Float32ToField2Float64)
+	compare(t, "float64 to float32", fixture.Field1[float64]{
+		ValToVal: 10.625, ValToPtr: 10.625, PtrToVal: xgo.ToPtr(10.625), PtrToPtr: xgo.ToPtr(10.625),
+	}, fixture.CopyField1Float64ToField2Float32)
+	compare(t, "string to string", fixture.Field1[string]{
+		ValToVal: "test1", ValToPtr: "test2", PtrToVal: xgo.ToPtr("test3"), PtrToPtr: xgo.ToPtr("test4"),
+	}, fixture.CopyField1StringToField2String)
+	compare(t, "custom type to custom type", fixture.Field1[fixture.Foo]{
+		ValToVal: "test1", ValToPtr: "test2", PtrToVal: xgo.ToPtr(fixture.Foo("test3")), PtrToPtr: xgo.ToPtr(fixture.Foo("test4")),
+	}, fixture.CopyField1FooToField2Bar)
+	compare(t, "nil or zero value", fixture.Field1[string]{}, fixture.CopyField1StringToField2String)
+
+	sample := fixture.ModelSample{ID: "ididididid", Order: 1, CreatedAt: now}
+	sample.Pos.Lat, sample.Pos.Lon = 1234.56, 6543.21
+	compare(t, "tag and anonymous struct", sample, fixture.CopyModelSampleToPbSample)
+
+	compare(t, "map fields", fixture.MapModel1{
+		Items:    map[string]fixture.Model1{"foo": {Foo: "foo", Bar: 100}},
+		PtrItems: map[string]*fixture.Model1{"foo": {Foo: "foo", Bar: 100}, "nil": nil},
+		Keys:     map[fixture.MapKey]int32{"foo": 100, "bar": 200},
+		Lists:    map[string][]fixture.Model1{"foo": {{Foo: "foo", Bar: 100}}},
+		Nested:   map[string]map[string]fixture.Model1{"foo": {"bar": {Foo: "bar", Bar: 200}}},
+	}, fixture.CopyMapModel1ToMapModel2)
+
+	base := fixture.EmbeddedBase{ID: "id", CreatedAt: now}
+	compare(t, "embedded to flat", fixture.Embedded{EmbeddedBase: base, Name: "R2D2"}, fixture.CopyEmbeddedToEmbeddedFlat)
+	compare(t, "flat to embedded", fixture.EmbeddedFlat{ID: "id", CreatedAt: now, Name: "R2D2"}, fixture.CopyEmbeddedFlatToEmbedded)
+	compare(t, "flat to embedded pointer", fixture.EmbeddedFlat{ID: "id", CreatedAt: now, Name: "R2D2"}, fixture.CopyEmbeddedFlatToEmbeddedPtr)
+	compare(t, "flat zero value to embedded pointer", fixture.EmbeddedFlat{Name: "R2D2"}, fixture.CopyEmbeddedFlatToEmbeddedPtr)
+	compare(t, "embedded pointer to embedded pointer", fixture.EmbeddedPtr{EmbeddedBase: &base, Name: "R2D2"}, fixture.CopyEmbeddedPtrToEmbeddedPtrDTO)
+	compare(t, "nil embedded pointer", fixture.EmbeddedPtr{Name: "R2D2"}, fixture.CopyEmbeddedPtrToEmbeddedPtrDTO)
+	compare(t, "embedded to embedded", fixture.Embedded{EmbeddedBase: base, Name: "R2D2"}, fixture.CopyEmbeddedToEmbeddedDTO)
+
+	compare(t, "arrays", fixture.ArrayModel1{
+		Points: [3]fixture.Point1{{X: 1}, {X: 2}, {X: 3}},
+		ID:     [4]byte{1, 2, 3, 4},
+		List:   []fixture.Point1{{X: 1}, {X: 2}, {X: 3}},
+		Fixed:  [2]fixture.Point1{{X: 1}, {X: 2}},
+		Raw:    []byte{1, 2},
+	}, fixture.CopyArrayModel1ToArrayModel2)
+	compare(t, "arrays zero value", fixture.ArrayModel1{}, fixture.CopyArrayModel1ToArrayModel2)
+
+	tagModel := fixture.TagModel{
+		ID:       "xxxx",
+		Name:     "R2D2",
+		Password: "secret",
+		Email:    "r2d2@example.com",
+		Address:  &fixture.TagAddress{City: "Tokyo"},
+		Tags:     []string{"a"},
+		Labels:   []string{"b"},
+		Meta:     map[string]string{"c": "d"},
+		Count:    xgo.ToPtr(1),
+	}
+	compare(t, "tags", tagModel, fixture.CopyTagModelToTagDTO)
+	compare(t, "tags for the target type", tagModel, fixture.CopyTagModelToTagPB)
+	compare(t, "tags zero value", fixture.TagModel{Email: "r2d2@example.com"}, fixture.CopyTagModelToTagDTO)
+
+	pathModel := fixture.PathModel{
+		Name:    "R2D2",
+		Address: &fixture.PathAddress{City: "Tokyo", Geo: &fixture.PathGeo{Lat: 35.6}},
+	}
+	compare(t, "flatten", pathModel, fixture.CopyPathModelToPathDTO)
+	compare(t, "flatten nil pointer", fixture.PathModel{Name: "R2D2"}, fixture.CopyPathModelToPathDTO)
+	compare(t, "unflatten", fixture.PathDTO{Name: "R2D2", AddressCity: "Tokyo", Lat: 35.6}, fixture.CopyPathDTOToPathModel)
+	compare(t, "unflatten zero value", fixture.PathDTO{AddressCity: "Tokyo"}, fixture.CopyPathDTOToPathModel)
+
+	textQuery := fixture.TextQuery{
+		ID:      "0a0b",
+		Page:    "-3",
+		Total:   "100",
+		Active:  "true",
+		Score:   "1.5",
+		Timeout: "1m30s",
+		Status:  "1",
+		Limit:   xgo.ToPtr("10"),
+		Tags:    []string{"1", "", "2"},
+	}
+	compare(t, "parse strings", textQuery, fixture.CopyTextQueryToTextModel)
+	compare(t, "parse empty strings", fixture.TextQuery{Limit: xgo.ToPtr("")}, fixture.CopyTextQueryToTextModel)
+	compare(t, "format strings", fixture.TextModel{
+		ID:      fixture.TextID{0x0a, 0x0b},
+		Page:    65,
+		Active:  true,
+		Score:   1.5,
+		Timeout: 90 * time.Second,
+		Status:  1,
+		Limit:   xgo.ToPtr(66),
+		Tags:    []int{67},
+	}, fixture.CopyTextModelToTextQuery)
+	compare(t, "time to epoch units", fixture.EpochModel{
+		CreatedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 6, 1, 0, 0, 1, 500000000, time.UTC),
+		DeletedAt: xgo.ToPtr(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)),
+		StartedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndedAt:   time.Date(2025, 6, 1, 0, 0, 1, 500500000, time.UTC),
+		ExpiresAt: xgo.ToPtr(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)),
+		CheckedAt: time.Date(2025, 6, 1, 0, 0, 0, 1000, time.UTC),
+		SyncedAt:  time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	}, fixture.CopyEpochModelToEpochDTO)
+	compare(t, "epoch units to time", fixture.EpochDTO{
+		CreatedAt: 1748736000,
+		UpdatedAt: xgo.ToPtr(int64(1748736001500)),
+		DeletedAt: 1748822400000000000,
+		StartedAt: 1748736000,
+		EndedAt:   1748736001500.5,
+		ExpiresAt: 1748822400000000000,
+		CheckedAt: xgo.ToPtr(uint64(1748736000000001)),
+		SyncedAt:  1.748736e18,
+	}, fixture.CopyEpochDTOToEpochModel)
+
+	t.Run("epoch out of range", func(t *testing.T) {
+		t.Parallel()
+		src := fixture.EpochModel{
+			StartedAt: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+			CheckedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		}
+		err := fixture.CopyEpochModelToEpochDTO(src, &fixture.EpochDTO{})
+		if err == nil || !strings.HasPrefix(err.Error(), "StartedAt: ") {
+			t.Errorf("should be error of StartedAt but: %v", err)
+		}
+		if err := xgo.DeepCopy(src, &fixture.EpochDTO{}); err == nil {
+			t.Error("should be error but not")
+		}
+	})
+	compare(t, "interfaces", fixture.IfaceModel{
+		Meta:  map[string]interface{}{"tags": []string{"foo"}},
+		Count: 3,
+		Items: []interface{}{"bar", &fixture.ModelA{}},
+		Attrs: map[string]interface{}{"n": 1.5},
+	}, fixture.CopyIfaceModelToIfaceDTO)
+
+	t.Run("interface value is not shared", func(t *testing.T) {
+		t.Parallel()
+		tags := []string{"foo"}
+		got := fixture.IfaceDTO{}
+		if err := fixture.CopyIfaceModelToIfaceDTO(fixture.IfaceModel{Meta: tags}, &got); err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		tags[0] = "bar"
+		if diff := cmp.Diff([]string{"foo"}, got.Meta); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		t.Parallel()
+		for _, src := range []fixture.TextQuery{{ID: "xxxx"}, {Page: "300"}, {Active: "yes"}, {Timeout: "90"}, {Tags: []string{"x"}}} {
+			if err := xgo.DeepCopy(src, &fixture.TextModel{}); err == nil {
+				t.Errorf("DeepCopy should be error for %#v but not", src)
+			}
+			if err := fixture.CopyTextQueryToTextModel(src, &fixture.TextModel{}); err == nil {
+				t.Errorf("the generated function should be error for %#v but not", src)
+			}
+		}
+	})
+
+	t.Run("required field", func(t *testing.T) {
+		t.Parallel()
+		if err := xgo.DeepCopy(fixture.TagModel{}, &fixture.TagDTO{}); err == nil {
+			t.Error("DeepCopy should be error but not")
+		}
+		if err := fixture.CopyTagModelToTagDTO(fixture.TagModel{}, &fixture.TagDTO{}); err == nil {
+			t.Error("the generated function should be error but not")
+		}
+	})
+}
@@ -0,0 +1,416 @@
+// Package fixture contains the models of the xgo.DeepCopy test cases and the copy functions generated for them.
+package fixture
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//go:generate go run github.com/glassonion1/xgo/cmd/xgo-copygen -type FromPtrTestdata:ToPtrTestdata,FromPtrTestdata:ModelA,Int64Field:Int32Field,Int32Field:Int64Field,TimeModelA:TimeModelB,TimeModelC:TimeModelD,TimeModelD:TimeModelC,TimeModelC:TimeModelE,TimeModelE:TimeModelC,PrivateField:PrivateField,Example1:Example2,[]*Example1:[]*Example2,PtrSlice1:PtrSlice2,Field1[int]:Field2[int],Field1[int32]:Field2[int64],Field1[int64]:Field2[int32],Field1[float32]:Field2[float64],Field1[float64]:Field2[float32],Field1[string]:Field2[string],Field1[Foo]:Field2[Bar],ModelSample:PbSample,MapModel1:MapModel2,Embedded:EmbeddedFlat,EmbeddedFlat:Embedded,EmbeddedFlat:EmbeddedPtr,EmbeddedPtr:EmbeddedPtrDTO,Embedded:EmbeddedDTO,ArrayModel1:ArrayModel2,TagModel:TagDTO,TagModel:TagPB,PathModel:PathDTO,PathDTO:PathModel,TextQuery:TextModel,TextModel:TextQuery,EpochModel:EpochDTO,EpochDTO:EpochModel,IfaceModel:IfaceDTO
+
+type ModelC struct {
+	Field string
+}
+
+type ModelD struct {
+	Field string
+}
+
+type ModelA struct {
+	Field     string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	Model     ModelC
+}
+
+type ModelB struct {
+	Field     string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	Model     ModelD
+}
+
+type Int32Field struct {
+	ID int32
+}
+
+type Int64Field struct {
+	ID int64
+}
+
+type FromPtrTestdata struct {
+	StructToStruct ModelA
+	StructToPtr    ModelA
+	PtrToStruct    *ModelA
+	PtrToPtr       *ModelA
+}
+
+type ToPtrTestdata struct {
+	StructToStruct ModelB
+	StructToPtr    *ModelB
+	PtrToStruct    ModelB
+	PtrToPtr       *ModelB
+}
+
+type TimeModelA struct {
+	CreatedAt *time.Time
+	UpdatedAt time.Time
+}
+
+type TimeModelB struct {
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type TimeModelC struct {
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  *time.Time
+	ReplacedAt *time.Time
+}
+
+type TimeModelD struct {
+	CreatedAt  int64
+	UpdatedAt  *int64
+	DeletedAt  int64
+	ReplacedAt *int64
+}
+
+type TimeModelE struct {
+	CreatedAt  string
+	UpdatedAt  *string
+	DeletedAt  string
+	ReplacedAt *string
+}
+
+type PrivateField struct {
+	ID    string
+	name  string
+	state int
+}
+
+type Model1 struct {
+	Foo string
+	Bar int
+}
+
+type Model2 struct {
+	Foo string
+	Bar int
+}
+
+type Example1 struct {
+	ID         string
+	Name       string
+	State      int
+	Tests      []string
+	StructPtrs []*Model1
+	Structs    []Model1
+}
+
+type Example2 struct {
+	ID         string
+	Name       string
+	State      int
+	Tests      []string
+	StructPtrs []*Model2
+	Structs    []Model2
+}
+
+type Foo string
+
+type Bar string
+
+type PtrSlice1 struct {
+	StructToStruct []Foo
+	StructToPtr    []Foo
+	PtrToStruct    []*Foo
+	PtrToPtr       []*Foo
+}
+
+type PtrSlice2 struct {
+	StructToStruct []Bar
+	StructToPtr    []*Bar
+	PtrToStruct    []Bar
+	PtrToPtr       []*Bar
+}
+
+type Field1[T any] struct {
+	ValToVal T
+	ValToPtr T
+	PtrToVal *T
+	PtrToPtr *T
+}
+
+type Field2[S any] struct {
+	ValToVal S
+	ValToPtr *S
+	PtrToVal S
+	PtrToPtr *S
+}
+
+type ModelSample struct {
+	ID        string `copier:"Id"`
+	Order     int64
+	CreatedAt time.Time
+	Pos       struct {
+		Lat float64
+		Lon float64
+	}
+}
+
+type PbPos struct {
+	Lat float64
+	Lon float64
+}
+
+type PbSample struct {
+	Id        string
+	Order     int64
+	CreatedAt string
+	Pos       *PbPos
+}
+
+type MapKey string
+
+type MapModel1 struct {
+	Items    map[string]Model1
+	PtrItems map[string]*Model1
+	Keys     map[MapKey]int32
+	Lists    map[string][]Model1
+	Nested   map[string]map[string]Model1
+	Nil      map[string]Model1
+}
+
+type MapModel2 struct {
+	Items    map[string]Model2
+	PtrItems map[string]Model2
+	Keys     map[string]int64
+	Lists    map[string][]*Model2
+	Nested   map[string]map[string]Model2
+	Nil      map[string]Model2
+}
+
+type EmbeddedBase struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+type EmbeddedBaseDTO struct {
+	ID        string
+	CreatedAt int64
+}
+
+type Embedded struct {
+	EmbeddedBase
+	Name string
+}
+
+type EmbeddedPtr struct {
+	*EmbeddedBase
+	Name string
+}
+
+type EmbeddedDTO struct {
+	EmbeddedBaseDTO
+	Name string
+}
+
+type EmbeddedPtrDTO struct {
+	*EmbeddedBaseDTO
+	Name string
+}
+
+type EmbeddedFlat struct {
+	ID        string
+	CreatedAt time.Time
+	Name      string
+}
+
+type Point1 struct {
+	X int32
+}
+
+type Point2 struct {
+	X int64
+}
+
+type ArrayModel1 struct {
+	Points [3]Point1
+	ID     [4]byte
+	List   []Point1
+	Fixed  [2]Point1
+	Nil    []Point1
+	Raw    []byte
+}
+
+type ArrayModel2 struct {
+	Points [3]Point2
+	ID     []byte
+	List   [2]Point2
+	Fixed  []*Point2
+	Nil    [2]Point2
+	Raw    [4]byte
+}
+
+type TagAddress struct {
+	City string
+}
+
+type TagModel struct {
+	ID       string            `copier:"name=Id;to=TagPB.UserId"`
+	Name     string            `copier:",omitempty"`
+	Password string            `copier:"-"`
+	Email    string            `copier:",required"`
+	Address  *TagAddress       `copier:",deep"`
+	Tags     []string          `copier:",deep"`
+	Labels   []string          `copier:",shallow"`
+	Meta     map[string]string `copier:",deep"`
+	Count    *int              `copier:",deep"`
+}
+
+type TagDTO struct {
+	Id       string
+	Name     string
+	Password string
+	Email    string
+	Address  *TagAddress
+	Tags     []string
+	Labels   []string
+	Meta     map[string]string
+	Count    *int
+	Secret   string `copier:"-"`
+}
+
+type TagPB struct {
+	UserId string
+	Name   string
+	Email  string
+	Secret string `copier:"-"`
+}
+
+// TagRequiredDTO has a required field that no field of TagModel is copied to
+type TagRequiredDTO struct {
+	Token string `copier:",required"`
+}
+
+type PathGeo struct {
+	Lat float64
+}
+
+type PathAddress struct {
+	City string
+	Geo  *PathGeo
+}
+
+type PathModel struct {
+	Name    string
+	Address *PathAddress
+}
+
+// PathDTO flattens the nested fields of PathModel
+type PathDTO struct {
+	Name        string
+	AddressCity string  `copier:"Address.City"`
+	Lat         float64 `copier:"Address.Geo.Lat,omitempty"`
+}
+
+// TextID is a uuid-like type that has the text methods
+type TextID [2]byte
+
+func (id TextID) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(id[:])), nil
+}
+
+func (id *TextID) UnmarshalText(b []byte) error {
+	if len(b) != hex.EncodedLen(len(id)) {
+		return errors.New("invalid id")
+	}
+	_, err := hex.Decode(id[:], b)
+	return err
+}
+
+type TextStatus int
+
+func (s TextStatus) String() string {
+	return fmt.Sprintf("status-%d", int(s))
+}
+
+// TextMessage is a struct like a protobuf message that has String for debugging
+type TextMessage struct {
+	Seconds int64
+}
+
+func (m *TextMessage) String() string {
+	return fmt.Sprintf("seconds:%d", m.Seconds)
+}
+
+// TextMessageModel has a struct that is not stringified to TextQuery
+type TextMessageModel struct {
+	Page *TextMessage
+}
+
+type TextModel struct {
+	ID      TextID
+	Page    int8
+	Total   uint
+	Active  bool
+	Score   float32
+	Timeout time.Duration
+	Status  TextStatus
+	Limit   *int
+	Tags    []int
+}
+
+type TextQuery struct {
+	ID      string
+	Page    string
+	Total   string
+	Active  string
+	Score   string
+	Timeout string
+	Status  string
+	Limit   *string
+	Tags    []string
+}
+
+// Epoch is a named number of the epoch
+type Epoch int64
+
+type EpochModel struct {
+	CreatedAt time.Time `copier:",unix"`
+	UpdatedAt time.Time `copier:",unixmilli"`
+	DeletedAt *time.Time
+	StartedAt time.Time `copier:",unix"`
+	EndedAt   time.Time `copier:",unixmilli"`
+	ExpiresAt *time.Time
+	CheckedAt time.Time `copier:",unixmicro"`
+	SyncedAt  time.Time
+}
+
+type EpochDTO struct {
+	CreatedAt int64
+	UpdatedAt *int64 `copier:",unixmilli"`
+	DeletedAt int64
+	StartedAt int32
+	EndedAt   float64
+	ExpiresAt Epoch
+	CheckedAt *uint64
+	SyncedAt  float32
+}
+
+type IfaceModel struct {
+	Meta  interface{}
+	Count interface{}
+	Items []interface{}
+	Attrs map[string]interface{}
+}
+
+type IfaceDTO struct {
+	Meta  interface{}
+	Count int
+	Items []interface{}
+	Attrs map[string]interface{}
+}
@@ -0,0 +1,863 @@
+// Code generated by xgo-copygen. DO NOT EDIT.
+
+package fixture
+
+import (
+	"errors"
+	"fmt"
+	"github.com/glassonion1/xgo"
+	"math"
+	"strconv"
+	"time"
+)
+
+// CopyFromPtrTestdataToToPtrTestdata copies FromPtrTestdata to ToPtrTestdata like xgo.DeepCopy does.
+func CopyFromPtrTestdataToToPtrTestdata(src FromPtrTestdata, dst *ToPtrTestdata) error {
+	var v1 ModelB
+	if err := copyModelAToModelB(src.StructToStruct, &v1); err != nil {
+		return fmt.Errorf("StructToStruct: %v", err)
+	}
+	dst.StructToStruct = v1
+	var v2 ModelB
+	if err := copyModelAToModelB(src.StructToPtr, &v2); err != nil {
+		return fmt.Errorf("StructToPtr: %v", err)
+	}
+	dst.StructToPtr = &v2
+	if src.PtrToStruct != nil {
+		var v3 ModelB
+		if err := copyModelAToModelB((*src.PtrToStruct), &v3); err != nil {
+			return fmt.Errorf("PtrToStruct: %v", err)
+		}
+		dst.PtrToStruct = v3
+	}
+	if src.PtrToPtr != nil {
+		var v4 ModelB
+		if err := copyModelAToModelB((*src.PtrToPtr), &v4); err != nil {
+			return fmt.Errorf("PtrToPtr: %v", err)
+		}
+		dst.PtrToPtr = &v4
+	}
+	return nil
+}
+
+// CopyFromPtrTestdataToModelA copies FromPtrTestdata to ModelA like xgo.DeepCopy does.
+func CopyFromPtrTestdataToModelA(src FromPtrTestdata, dst *ModelA) error {
+	return nil
+}
+
+// CopyInt64FieldToInt32Field copies Int64Field to Int32Field like xgo.DeepCopy does.
+func CopyInt64FieldToInt32Field(src Int64Field, dst *Int32Field) error {
+	dst.ID = int32(src.ID)
+	return nil
+}
+
+// CopyInt32FieldToInt64Field copies Int32Field to Int64Field like xgo.DeepCopy does.
+func CopyInt32FieldToInt64Field(src Int32Field, dst *Int64Field) error {
+	dst.ID = int64(src.ID)
+	return nil
+}
+
+// CopyTimeModelAToTimeModelB copies TimeModelA to TimeModelB like xgo.DeepCopy does.
+func CopyTimeModelAToTimeModelB(src TimeModelA, dst *TimeModelB) error {
+	if src.CreatedAt != nil {
+		dst.CreatedAt = (*src.CreatedAt)
+	}
+	v1 := src.UpdatedAt
+	dst.UpdatedAt = &v1
+	return nil
+}
+
+// CopyTimeModelCToTimeModelD copies TimeModelC to TimeModelD like xgo.DeepCopy does.
+func CopyTimeModelCToTimeModelD(src TimeModelC, dst *TimeModelD) error {
+	t1 := src.CreatedAt
+	v2 := t1.UnixNano()
+	dst.CreatedAt = v2
+	t3 := src.UpdatedAt
+	v4 := t3.UnixNano()
+	dst.UpdatedAt = &v4
+	if src.DeletedAt != nil {
+		t5 := (*src.DeletedAt)
+		v6 := t5.UnixNano()
+		dst.DeletedAt = v6
+	}
+	if src.ReplacedAt != nil {
+		t7 := (*src.ReplacedAt)
+		v8 := t7.UnixNano()
+		dst.ReplacedAt = &v8
+	}
+	return nil
+}
+
+// CopyTimeModelDToTimeModelC copies TimeModelD to TimeModelC like xgo.DeepCopy does.
+func CopyTimeModelDToTimeModelC(src TimeModelD, dst *TimeModelC) error {
+	dst.CreatedAt = time.Unix(0, src.CreatedAt)
+	if src.UpdatedAt != nil {
+		dst.UpdatedAt = time.Unix(0, (*src.UpdatedAt))
+	}
+	v1 := time.Unix(0, src.DeletedAt)
+	dst.DeletedAt = &v1
+	if src.ReplacedAt != nil {
+		v2 := time.Unix(0, (*src.ReplacedAt))
+		dst.ReplacedAt = &v2
+	}
+	return nil
+}
+
+// CopyTimeModelCToTimeModelE copies TimeModelC to TimeModelE like xgo.DeepCopy does.
+func CopyTimeModelCToTimeModelE(src TimeModelC, dst *TimeModelE) error {
+	dst.CreatedAt = src.CreatedAt.Format(time.RFC3339Nano)
+	v1 := src.UpdatedAt.Format(time.RFC3339Nano)
+	dst.UpdatedAt = &v1
+	if src.DeletedAt != nil {
+		dst.DeletedAt = (*src.DeletedAt).Format(time.RFC3339Nano)
+	}
+	if src.ReplacedAt != nil {
+		v2 := (*src.ReplacedAt).Format(time.RFC3339Nano)
+		dst.ReplacedAt = &v2
+	}
+	return nil
+}
+
+// CopyTimeModelEToTimeModelC copies TimeModelE to TimeModelC like xgo.DeepCopy does.
+func CopyTimeModelEToTimeModelC(src TimeModelE, dst *TimeModelC) error {
+	if src.CreatedAt != "" {
+		v1, err := time.Parse(time.RFC3339Nano, src.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("CreatedAt: %v", err)
+		}
+		dst.CreatedAt = v1
+	}
+	if src.UpdatedAt != nil {
+		if (*src.UpdatedAt) != "" {
+			v2, err := time.Parse(time.RFC3339Nano, (*src.UpdatedAt))
+			if err != nil {
+				return fmt.Errorf("UpdatedAt: %v", err)
+			}
+			dst.UpdatedAt = v2
+		}
+	}
+	if src.DeletedAt != "" {
+		v3, err := time.Parse(time.RFC3339Nano, src.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("DeletedAt: %v", err)
+		}
+		dst.DeletedAt = &v3
+	}
+	if src.ReplacedAt != nil {
+		if (*src.ReplacedAt) != "" {
+			v4, err := time.Parse(time.RFC3339Nano, (*src.ReplacedAt))
+			if err != nil {
+				return fmt.Errorf("ReplacedAt: %v", err)
+			}
+			dst.ReplacedAt = &v4
+		}
+	}
+	return nil
+}
+
+// CopyPrivateFieldToPrivateField copies PrivateField to PrivateField like xgo.DeepCopy does.
+func CopyPrivateFieldToPrivateField(src PrivateField, dst *PrivateField) error {
+	dst.ID = src.ID
+	return nil
+}
+
+// CopyExample1ToExample2 copies Example1 to Example2 like xgo.DeepCopy does.
+func CopyExample1ToExample2(src Example1, dst *Example2) error {
+	dst.ID = src.ID
+	dst.Name = src.Name
+	dst.State = src.State
+	dst.Tests = src.Tests
+	if src.StructPtrs != nil {
+		dst.StructPtrs = make([]*Model2, len(src.StructPtrs), cap(src.StructPtrs))
+		for i1 := range src.StructPtrs {
+			dst.StructPtrs[i1] = (*Model2)(src.StructPtrs[i1])
+		}
+	}
+	if src.Structs != nil {
+		dst.Structs = make([]Model2, len(src.Structs), cap(src.Structs))
+		for i2 := range src.Structs {
+			dst.Structs[i2] = Model2(src.Structs[i2])
+		}
+	}
+	return nil
+}
+
+// CopySliceExample1ToSliceExample2 copies []*Example1 to []*Example2 like xgo.DeepCopy does.
+func CopySliceExample1ToSliceExample2(src []*Example1, dst *[]*Example2) error {
+	if src != nil {
+		(*dst) = make([]*Example2, len(src), cap(src))
+		for i1 := range src {
+			if src[i1] != nil {
+				var v2 Example2
+				if err := CopyExample1ToExample2((*src[i1]), &v2); err != nil {
+					return err
+				}
+				(*dst)[i1] = &v2
+			}
+		}
+	}
+	return nil
+}
+
+// CopyPtrSlice1ToPtrSlice2 copies PtrSlice1 to PtrSlice2 like xgo.DeepCopy does.
+func CopyPtrSlice1ToPtrSlice2(src PtrSlice1, dst *PtrSlice2) error {
+	if src.StructToStruct != nil {
+		dst.StructToStruct = make([]Bar, len(src.StructToStruct), cap(src.StructToStruct))
+		for i1 := range src.StructToStruct {
+			dst.StructToStruct[i1] = Bar(src.StructToStruct[i1])
+		}
+	}
+	if src.StructToPtr != nil {
+		dst.StructToPtr = make([]*Bar, len(src.StructToPtr), cap(src.StructToPtr))
+		for i2 := range src.StructToPtr {
+			v3 := Bar(src.StructToPtr[i2])
+			dst.StructToPtr[i2] = &v3
+		}
+	}
+	if src.PtrToStruct != nil {
+		dst.PtrToStruct = make([]Bar, len(src.PtrToStruct), cap(src.PtrToStruct))
+		for i4 := range src.PtrToStruct {
+			if src.PtrToStruct[i4] != nil {
+				dst.PtrToStruct[i4] = Bar((*src.PtrToStruct[i4]))
+			}
+		}
+	}
+	if src.PtrToPtr != nil {
+		dst.PtrToPtr = make([]*Bar, len(src.PtrToPtr), cap(src.PtrToPtr))
+		for i5 := range src.PtrToPtr {
+			dst.PtrToPtr[i5] = (*Bar)(src.PtrToPtr[i5])
+		}
+	}
+	return nil
+}
+
+// CopyField1IntToField2Int copies Field1[int] to Field2[int] like xgo.DeepCopy does.
+func CopyField1IntToField2Int(src Field1[int], dst *Field2[int]) error {
+	dst.ValToVal = src.ValToVal
+	v1 := src.ValToPtr
+	dst.ValToPtr = &v1
+	if src.PtrToVal != nil {
+		dst.PtrToVal = (*src.PtrToVal)
+	}
+	dst.PtrToPtr = src.PtrToPtr
+	return nil
+}
+
+// CopyField1Int32ToField2Int64 copies Field1[int32] to Field2[int64] like xgo.DeepCopy does.
+func CopyField1Int32ToField2Int64(src Field1[int32], dst *Field2[int64]) error {
+	dst.ValToVal = int64(src.ValToVal)
+	v1 := int64(src.ValToPtr)
+	dst.ValToPtr = &v1
+	if src.PtrToVal != nil {
+		dst.PtrToVal = int64((*src.PtrToVal))
+	}
+	if src.PtrToPtr != nil {
+		v2 := int64((*src.PtrToPtr))
+		dst.PtrToPtr = &v2
+	}
+	return nil
+}
+
+// CopyField1Int64ToField2Int32 copies Field1[int64] to Field2[int32] like xgo.DeepCopy does.
+func CopyField1Int64ToField2Int32(src Field1[int64], dst *Field2[int32]) error {
+	dst.ValToVal = int32(src.ValToVal)
+	v1 := int32(src.ValToPtr)
+	dst.ValToPtr = &v1
+	if src.PtrToVal != nil {
+		dst.PtrToVal = int32((*src.PtrToVal))
+	}
+	if src.PtrToPtr != nil {
+		v2 := int32((*src.PtrToPtr))
+		dst.PtrToPtr = &v2
+	}
+	return nil
+}
+
+// CopyField1Float32ToField2Float64 copies Field1[float32] to Field2[float64] like xgo.DeepCopy does.
+func CopyField1Float32ToField2Float64(src Field1[float32], dst *Field2[float64]) error {
+	dst.ValToVal = float64(src.ValToVal)
+	v1 := float64(src.ValToPtr)
+	dst.ValToPtr = &v1
+	if src.PtrToVal != nil {
+		dst.PtrToVal = float64((*src.PtrToVal))
+	}
+	if src.PtrToPtr != nil {
+		v2 := float64((*src.PtrToPtr))
+		dst.PtrToPtr = &v2
+	}
+	return nil
+}
+
+// CopyField1Float64ToField2Float32 copies Field1[float64] to Field2[float32] like xgo.DeepCopy does.
+func CopyField1Float64ToField2Float32(src Field1[float64], dst *Field2[float32]) error {
+	dst.ValToVal = float32(src.ValToVal)
+	v1 := float32(src.ValToPtr)
+	dst.ValToPtr = &v1
+	if src.PtrToVal != nil {
+		dst.PtrToVal = float32((*src.PtrToVal))
+	}
+	if src.PtrToPtr != nil {
+		v2 := float32((*src.PtrToPtr))
+		dst.PtrToPtr = &v2
+	}
+	return nil
+}
+
+// CopyField1StringToField2String copies Field1[string] to Field2[string] like xgo.DeepCopy does.
+func CopyField1StringToField2String(src Field1[string], dst *Field2[string]) error {
+	dst.ValToVal = src.ValToVal
+	v1 := src.ValToPtr
+	dst.ValToPtr = &v1
+	if src.PtrToVal != nil {
+		dst.PtrToVal = (*src.PtrToVal)
+	}
+	dst.PtrToPtr = src.PtrToPtr
+	return nil
+}
+
+// CopyField1FooToField2Bar copies Field1[Foo] to Field2[Bar] like xgo.DeepCopy does.
+func CopyField1FooToField2Bar(src Field1[Foo], dst *Field2[Bar]) error {
+	dst.ValToVal = Bar(src.ValToVal)
+	v1 := Bar(src.ValToPtr)
+	dst.ValToPtr = &v1
+	if src.PtrToVal != nil {
+		dst.PtrToVal = Bar((*src.PtrToVal))
+	}
+	dst.PtrToPtr = (*Bar)(src.PtrToPtr)
+	return nil
+}
+
+// CopyModelSampleToPbSample copies ModelSample to PbSample like xgo.DeepCopy does.
+func CopyModelSampleToPbSample(src ModelSample, dst *PbSample) error {
+	dst.Id = src.ID
+	dst.Order = src.Order
+	dst.CreatedAt = src.CreatedAt.Format(time.RFC3339Nano)
+	v1 := PbPos(src.Pos)
+	dst.Pos = &v1
+	return nil
+}
+
+// CopyMapModel1ToMapModel2 copies MapModel1 to MapModel2 like xgo.DeepCopy does.
+func CopyMapModel1ToMapModel2(src MapModel1, dst *MapModel2) error {
+	if src.Items != nil {
+		dst.Items = make(map[string]Model2, len(src.Items))
+		for k1, v2 := range src.Items {
+			var k3 string
+			k3 = k1
+			var v4 Model2
+			v4 = Model2(v2)
+			dst.Items[k3] = v4
+		}
+	}
+	if src.PtrItems != nil {
+		dst.PtrItems = make(map[string]Model2, len(src.PtrItems))
+		for k5, v6 := range src.PtrItems {
+			var k7 string
+			k7 = k5
+			var v8 Model2
+			if v6 != nil {
+				v8 = Model2((*v6))
+			}
+			dst.PtrItems[k7] = v8
+		}
+	}
+	if src.Keys != nil {
+		dst.Keys = make(map[string]int64, len(src.Keys))
+		for k9, v10 := range src.Keys {
+			var k11 string
+			k11 = string(k9)
+			var v12 int64
+			v12 = int64(v10)
+			dst.Keys[k11] = v12
+		}
+	}
+	if src.Lists != nil {
+		dst.Lists = make(map[string][]*Model2, len(src.Lists))
+		for k13, v14 := range src.Lists {
+			var k15 string
+			k15 = k13
+			var v16 []*Model2
+			var v17 []*Model2
+			if v14 != nil {
+				v17 = make([]*Model2, len(v14), cap(v14))
+				for i18 := range v14 {
+					v19 := Model2(v14[i18])
+					v17[i18] = &v19
+				}
+			}
+			v16 = v17
+			dst.Lists[k15] = v16
+		}
+	}
+	if src.Nested != nil {
+		dst.Nested = make(map[string]map[string]Model2, len(src.Nested))
+		for k20, v21 := range src.Nested {
+			var k22 string
+			k22 = k20
+			var v23 map[string]Model2
+			var v24 map[string]Model2
+			if v21 != nil {
+				v24 = make(map[string]Model2, len(v21))
+				for k25, v26 := range v21 {
+					var k27 string
+					k27 = k25
+					var v28 Model2
+					v28 = Model2(v26)
+					v24[k27] = v28
+				}
+			}
+			v23 = v24
+			dst.Nested[k22] = v23
+		}
+	}
+	if src.Nil != nil {
+		dst.Nil = make(map[string]Model2, len(src.Nil))
+		for k29, v30 := range src.Nil {
+			var k31 string
+			k31 = k29
+			var v32 Model2
+			v32 = Model2(v30)
+			dst.Nil[k31] = v32
+		}
+	}
+	return nil
+}
+
+// CopyEmbeddedToEmbeddedFlat copies Embedded to EmbeddedFlat like xgo.DeepCopy does.
+func CopyEmbeddedToEmbeddedFlat(src Embedded, dst *EmbeddedFlat) error {
+	dst.ID = src.EmbeddedBase.ID
+	dst.CreatedAt = src.EmbeddedBase.CreatedAt
+	dst.Name = src.Name
+	return nil
+}
+
+// CopyEmbeddedFlatToEmbedded copies EmbeddedFlat to Embedded like xgo.DeepCopy does.
+func CopyEmbeddedFlatToEmbedded(src EmbeddedFlat, dst *Embedded) error {
+	dst.EmbeddedBase.ID = src.ID
+	dst.EmbeddedBase.CreatedAt = src.CreatedAt
+	dst.Name = src.Name
+	return nil
+}
+
+// CopyEmbeddedFlatToEmbeddedPtr copies EmbeddedFlat to EmbeddedPtr like xgo.DeepCopy does.
+func CopyEmbeddedFlatToEmbeddedPtr(src EmbeddedFlat, dst *EmbeddedPtr) error {
+	if src.ID != "" {
+		if dst.EmbeddedBase == nil {
+			dst.EmbeddedBase = new(EmbeddedBase)
+		}
+		dst.EmbeddedBase.ID = src.ID
+	}
+	if src.CreatedAt != (time.Time{}) {
+		if dst.EmbeddedBase == nil {
+			dst.EmbeddedBase = new(EmbeddedBase)
+		}
+		dst.EmbeddedBase.CreatedAt = src.CreatedAt
+	}
+	dst.Name = src.Name
+	return nil
+}
+
+// CopyEmbeddedPtrToEmbeddedPtrDTO copies EmbeddedPtr to EmbeddedPtrDTO like xgo.DeepCopy does.
+func CopyEmbeddedPtrToEmbeddedPtrDTO(src EmbeddedPtr, dst *EmbeddedPtrDTO) error {
+	if src.EmbeddedBase != nil {
+		if src.EmbeddedBase.ID != "" {
+			if dst.EmbeddedBaseDTO == nil {
+				dst.EmbeddedBaseDTO = new(EmbeddedBaseDTO)
+			}
+			dst.EmbeddedBaseDTO.ID = src.EmbeddedBase.ID
+		}
+	}
+	if src.EmbeddedBase != nil {
+		if src.EmbeddedBase.CreatedAt != (time.Time{}) {
+			if dst.EmbeddedBaseDTO == nil {
+				dst.EmbeddedBaseDTO = new(EmbeddedBaseDTO)
+			}
+			t1 := src.EmbeddedBase.CreatedAt
+			v2 := t1.UnixNano()
+			dst.EmbeddedBaseDTO.CreatedAt = v2
+		}
+	}
+	dst.Name = src.Name
+	return nil
+}
+
+// CopyEmbeddedToEmbeddedDTO copies Embedded to EmbeddedDTO like xgo.DeepCopy does.
+func CopyEmbeddedToEmbeddedDTO(src Embedded, dst *EmbeddedDTO) error {
+	dst.EmbeddedBaseDTO.ID = src.EmbeddedBase.ID
+	t1 := src.EmbeddedBase.CreatedAt
+	v2 := t1.UnixNano()
+	dst.EmbeddedBaseDTO.CreatedAt = v2
+	dst.Name = src.Name
+	return nil
+}
+
+// CopyArrayModel1ToArrayModel2 copies ArrayModel1 to ArrayModel2 like xgo.DeepCopy does.
+func CopyArrayModel1ToArrayModel2(src ArrayModel1, dst *ArrayModel2) error {
+	dst.Points = [3]Point2{}
+	for i1 := 0; i1 < len(src.Points) && i1 < len(dst.Points); i1++ {
+		var v2 Point2
+		if err := copyPoint1ToPoint2(src.Points[i1], &v2); err != nil {
+			return fmt.Errorf("Points: %v", err)
+		}
+		dst.Points[i1] = v2
+	}
+	dst.ID = make([]byte, len(src.ID), len(src.ID))
+	for i3 := range src.ID {
+		dst.ID[i3] = src.ID[i3]
+	}
+	if src.List != nil {
+		dst.List = [2]Point2{}
+		for i4 := 0; i4 < len(src.List) && i4 < len(dst.List); i4++ {
+			var v5 Point2
+			if err := copyPoint1ToPoint2(src.List[i4], &v5); err != nil {
+				return fmt.Errorf("List: %v", err)
+			}
+			dst.List[i4] = v5
+		}
+	}
+	dst.Fixed = make([]*Point2, len(src.Fixed), len(src.Fixed))
+	for i6 := range src.Fixed {
+		var v7 Point2
+		if err := copyPoint1ToPoint2(src.Fixed[i6], &v7); err != nil {
+			return fmt.Errorf("Fixed: %v", err)
+		}
+		dst.Fixed[i6] = &v7
+	}
+	if src.Nil != nil {
+		dst.Nil = [2]Point2{}
+		for i8 := 0; i8 < len(src.Nil) && i8 < len(dst.Nil); i8++ {
+			var v9 Point2
+			if err := copyPoint1ToPoint2(src.Nil[i8], &v9); err != nil {
+				return fmt.Errorf("Nil: %v", err)
+			}
+			dst.Nil[i8] = v9
+		}
+	}
+	if src.Raw != nil {
+		dst.Raw = [4]byte{}
+		for i10 := 0; i10 < len(src.Raw) && i10 < len(dst.Raw); i10++ {
+			dst.Raw[i10] = src.Raw[i10]
+		}
+	}
+	return nil
+}
+
+// CopyTagModelToTagDTO copies TagModel to TagDTO like xgo.DeepCopy does.
+func CopyTagModelToTagDTO(src TagModel, dst *TagDTO) error {
+	dst.Id = src.ID
+	if src.Name != "" {
+		dst.Name = src.Name
+	}
+	if !(src.Email != "") {
+		return errors.New("Email: the required field has the zero value")
+	}
+	dst.Email = src.Email
+	if src.Address != nil {
+		var v1 TagAddress
+		if err := copyTagAddressToTagAddress((*src.Address), &v1); err != nil {
+			return fmt.Errorf("Address: %v", err)
+		}
+		dst.Address = &v1
+	}
+	if src.Tags != nil {
+		dst.Tags = make([]string, len(src.Tags), cap(src.Tags))
+		for i2 := range src.Tags {
+			dst.Tags[i2] = src.Tags[i2]
+		}
+	}
+	dst.Labels = src.Labels
+	if src.Meta != nil {
+		dst.Meta = make(map[string]string, len(src.Meta))
+		for k3, v4 := range src.Meta {
+			var k5 string
+			k5 = k3
+			var v6 string
+			v6 = v4
+			dst.Meta[k5] = v6
+		}
+	}
+	if src.Count != nil {
+		v7 := (*src.Count)
+		dst.Count = &v7
+	}
+	return nil
+}
+
+// CopyTagModelToTagPB copies TagModel to TagPB like xgo.DeepCopy does.
+func CopyTagModelToTagPB(src TagModel, dst *TagPB) error {
+	dst.UserId = src.ID
+	if src.Name != "" {
+		dst.Name = src.Name
+	}
+	if !(src.Email != "") {
+		return errors.New("Email: the required field has the zero value")
+	}
+	dst.Email = src.Email
+	return nil
+}
+
+// CopyPathModelToPathDTO copies PathModel to PathDTO like xgo.DeepCopy does.
+func CopyPathModelToPathDTO(src PathModel, dst *PathDTO) error {
+	dst.Name = src.Name
+	if src.Address != nil {
+		dst.AddressCity = src.Address.City
+	}
+	if src.Address != nil && src.Address.Geo != nil {
+		if src.Address.Geo.Lat != 0 {
+			dst.Lat = src.Address.Geo.Lat
+		}
+	}
+	return nil
+}
+
+// CopyPathDTOToPathModel copies PathDTO to PathModel like xgo.DeepCopy does.
+func CopyPathDTOToPathModel(src PathDTO, dst *PathModel) error {
+	dst.Name = src.Name
+	if src.AddressCity != "" {
+		if dst.Address == nil {
+			dst.Address = new(PathAddress)
+		}
+		dst.Address.City = src.AddressCity
+	}
+	if src.Lat != 0 {
+		if dst.Address == nil {
+			dst.Address = new(PathAddress)
+		}
+		if dst.Address.Geo == nil {
+			dst.Address.Geo = new(PathGeo)
+		}
+		dst.Address.Geo.Lat = src.Lat
+	}
+	return nil
+}
+
+// CopyTextQueryToTextModel copies TextQuery to TextModel like xgo.DeepCopy does.
+func CopyTextQueryToTextModel(src TextQuery, dst *TextModel) error {
+	if src.ID != "" {
+		var v1 TextID
+		if err := v1.UnmarshalText([]byte(src.ID)); err != nil {
+			return fmt.Errorf("ID: %v", err)
+		}
+		dst.ID = v1
+	}
+	if src.Page != "" {
+		v2, err := strconv.ParseInt(src.Page, 10, 8)
+		if err != nil {
+			return fmt.Errorf("Page: %v", err)
+		}
+		dst.Page = int8(v2)
+	}
+	if src.Total != "" {
+		v3, err := strconv.ParseUint(src.Total, 10, 0)
+		if err != nil {
+			return fmt.Errorf("Total: %v", err)
+		}
+		dst.Total = uint(v3)
+	}
+	if src.Active != "" {
+		v4, err := strconv.ParseBool(src.Active)
+		if err != nil {
+			return fmt.Errorf("Active: %v", err)
+		}
+		dst.Active = v4
+	}
+	if src.Score != "" {
+		v5, err := strconv.ParseFloat(src.Score, 32)
+		if err != nil {
+			return fmt.Errorf("Score: %v", err)
+		}
+		dst.Score = float32(v5)
+	}
+	if src.Timeout != "" {
+		v6, err := time.ParseDuration(src.Timeout)
+		if err != nil {
+			return fmt.Errorf("Timeout: %v", err)
+		}
+		dst.Timeout = v6
+	}
+	if src.Status != "" {
+		v7, err := strconv.ParseInt(src.Status, 10, 0)
+		if err != nil {
+			return fmt.Errorf("Status: %v", err)
+		}
+		dst.Status = TextStatus(v7)
+	}
+	if src.Limit != nil {
+		if (*src.Limit) != "" {
+			v8, err := strconv.ParseInt((*src.Limit), 10, 0)
+			if err != nil {
+				return fmt.Errorf("Limit: %v", err)
+			}
+			v9 := int(v8)
+			dst.Limit = &v9
+		}
+	}
+	if src.Tags != nil {
+		dst.Tags = make([]int, len(src.Tags), cap(src.Tags))
+		for i10 := range src.Tags {
+			if src.Tags[i10] != "" {
+				v11, err := strconv.ParseInt(src.Tags[i10], 10, 0)
+				if err != nil {
+					return fmt.Errorf("Tags: %v", err)
+				}
+				dst.Tags[i10] = int(v11)
+			}
+		}
+	}
+	return nil
+}
+
+// CopyTextModelToTextQuery copies TextModel to TextQuery like xgo.DeepCopy does.
+func CopyTextModelToTextQuery(src TextModel, dst *TextQuery) error {
+	v1 := src.ID
+	b2, err := v1.MarshalText()
+	if err != nil {
+		return fmt.Errorf("ID: %v", err)
+	}
+	dst.ID = string(b2)
+	dst.Page = strconv.FormatInt(int64(src.Page), 10)
+	dst.Total = strconv.FormatUint(uint64(src.Total), 10)
+	dst.Active = strconv.FormatBool(src.Active)
+	dst.Score = strconv.FormatFloat(float64(src.Score), 'g', -1, 32)
+	v3 := src.Timeout
+	dst.Timeout = v3.String()
+	v4 := src.Status
+	dst.Status = v4.String()
+	if src.Limit != nil {
+		v5 := strconv.FormatInt(int64((*src.Limit)), 10)
+		dst.Limit = &v5
+	}
+	if src.Tags != nil {
+		dst.Tags = make([]string, len(src.Tags), cap(src.Tags))
+		for i6 := range src.Tags {
+			dst.Tags[i6] = strconv.FormatInt(int64(src.Tags[i6]), 10)
+		}
+	}
+	return nil
+}
+
+// CopyEpochModelToEpochDTO copies EpochModel to EpochDTO like xgo.DeepCopy does.
+func CopyEpochModelToEpochDTO(src EpochModel, dst *EpochDTO) error {
+	t1 := src.CreatedAt
+	v2 := t1.Unix()
+	dst.CreatedAt = v2
+	t3 := src.UpdatedAt
+	v4 := t3.UnixMilli()
+	dst.UpdatedAt = &v4
+	if src.DeletedAt != nil {
+		t5 := (*src.DeletedAt)
+		v6 := t5.UnixNano()
+		dst.DeletedAt = v6
+	}
+	t7 := src.StartedAt
+	v8 := t7.Unix()
+	if v8 < math.MinInt32 || v8 > math.MaxInt32 {
+		err := fmt.Errorf("%d: the number does not fit int32", v8)
+		return fmt.Errorf("StartedAt: %v", err)
+	}
+	dst.StartedAt = int32(v8)
+	t9 := src.EndedAt
+	dst.EndedAt = float64(t9.Unix())*float64(time.Second)/float64(time.Millisecond) + float64(t9.Nanosecond())/float64(time.Millisecond)
+	if src.ExpiresAt != nil {
+		t10 := (*src.ExpiresAt)
+		v11 := t10.UnixNano()
+		dst.ExpiresAt = Epoch(v11)
+	}
+	t12 := src.CheckedAt
+	v13 := t12.UnixMicro()
+	if v13 < 0 {
+		err := fmt.Errorf("%d: the number does not fit uint64", v13)
+		return fmt.Errorf("CheckedAt: %v", err)
+	}
+	v14 := uint64(v13)
+	dst.CheckedAt = &v14
+	t15 := src.SyncedAt
+	dst.SyncedAt = float32(float64(t15.Unix())*float64(time.Second) + float64(t15.Nanosecond()))
+	return nil
+}
+
+// CopyEpochDTOToEpochModel copies EpochDTO to EpochModel like xgo.DeepCopy does.
+func CopyEpochDTOToEpochModel(src EpochDTO, dst *EpochModel) error {
+	dst.CreatedAt = time.Unix(src.CreatedAt, 0)
+	if src.UpdatedAt != nil {
+		dst.UpdatedAt = time.UnixMilli((*src.UpdatedAt))
+	}
+	v1 := time.Unix(0, src.DeletedAt)
+	dst.DeletedAt = &v1
+	dst.StartedAt = time.Unix(int64(src.StartedAt), 0)
+	sec2, frac3 := math.Modf(src.EndedAt * float64(time.Millisecond) / float64(time.Second))
+	dst.EndedAt = time.Unix(int64(sec2), int64(math.Round(frac3*float64(time.Second))))
+	v4 := time.Unix(0, int64(src.ExpiresAt))
+	dst.ExpiresAt = &v4
+	if src.CheckedAt != nil {
+		dst.CheckedAt = time.UnixMicro(int64((*src.CheckedAt)))
+	}
+	sec5, frac6 := math.Modf(float64(src.SyncedAt) / float64(time.Second))
+	dst.SyncedAt = time.Unix(int64(sec5), int64(math.Round(frac6*float64(time.Second))))
+	return nil
+}
+
+// CopyIfaceModelToIfaceDTO copies IfaceModel to IfaceDTO like xgo.DeepCopy does.
+func CopyIfaceModelToIfaceDTO(src IfaceModel, dst *IfaceDTO) error {
+	if src.Meta != nil {
+		var v1 struct{ V interface{} }
+		if err := xgo.DeepCopy(struct{ V interface{} }{V: src.Meta}, &v1); err != nil {
+			return fmt.Errorf("Meta: %v", err)
+		}
+		dst.Meta = v1.V
+	}
+	if src.Count != nil {
+		var v2 struct{ V int }
+		if err := xgo.DeepCopy(struct{ V interface{} }{V: src.Count}, &v2); err != nil {
+			return fmt.Errorf("Count: %v", err)
+		}
+		dst.Count = v2.V
+	}
+	if src.Items != nil {
+		dst.Items = make([]interface{}, len(src.Items), cap(src.Items))
+		for i3 := range src.Items {
+			if src.Items[i3] != nil {
+				var v4 struct{ V interface{} }
+				if err := xgo.DeepCopy(struct{ V interface{} }{V: src.Items[i3]}, &v4); err != nil {
+					return fmt.Errorf("Items: %v", err)
+				}
+				dst.Items[i3] = v4.V
+			}
+		}
+	}
+	if src.Attrs != nil {
+		dst.Attrs = make(map[string]interface{}, len(src.Attrs))
+		for k5, v6 := range src.Attrs {
+			var k7 string
+			k7 = k5
+			var v8 interface{}
+			if v6 != nil {
+				var v9 struct{ V interface{} }
+				if err := xgo.DeepCopy(struct{ V interface{} }{V: v6}, &v9); err != nil {
+					return fmt.Errorf("Attrs: %v", err)
+				}
+				v8 = v9.V
+			}
+			dst.Attrs[k7] = v8
+		}
+	}
+	return nil
+}
+
+func copyModelAToModelB(src ModelA, dst *ModelB) error {
+	dst.Field = src.Field
+	dst.CreatedAt = src.CreatedAt
+	dst.UpdatedAt = src.UpdatedAt
+	dst.Model = ModelD(src.Model)
+	return nil
+}
+
+func copyPoint1ToPoint2(src Point1, dst *Point2) error {
+	dst.X = int64(src.X)
+	return nil
+}
+
+func copyTagAddressToTagAddress(src TagAddress, dst *TagAddress) error {
+	dst.City = src.City
+	return nil
+}
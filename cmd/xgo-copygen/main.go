@@ -0,0 +1,104 @@
+// Command xgo-copygen generates reflection-free copy functions that behave like xgo.DeepCopy.
+//
+// The generated functions follow the field-matching rules of xgo.DeepCopy:
+// fields are matched by name, the copier tag on a source field renames the destination field,
+// and the copier tag on a destination field names the source field.
+//
+// Usage:
+//
+//	//go:generate go run github.com/glassonion1/xgo/cmd/xgo-copygen -type User:UserDTO,Order:OrderDTO
+//
+// It emits functions like
+//
+//	func CopyUserToUserDTO(src User, dst *UserDTO) error
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultOutput = "xgo_copy_gen.go"
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("xgo-copygen: ")
+
+	typeFlag := flag.String("type", "", "comma-separated list of Src:Dst type pairs; must be set")
+	output := flag.String("output", "", "output file name; default <dir>/"+defaultOutput)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: xgo-copygen -type Src:Dst[,Src:Dst...] [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	pairs, err := parsePairs(*typeFlag)
+	if err != nil {
+		flag.Usage()
+		log.Fatal(err)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	out := *output
+	if out == "" {
+		out = filepath.Join(dir, defaultOutput)
+	}
+
+	src, err := generate(dir, filepath.Base(out), pairs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// pair is a pair of type expressions to generate a copy function for
+type pair struct {
+	src, dst string
+}
+
+func parsePairs(v string) ([]pair, error) {
+	if v == "" {
+		return nil, fmt.Errorf("-type must be set")
+	}
+	var pairs []pair
+	for _, p := range splitList(v) {
+		src, dst, ok := strings.Cut(strings.TrimSpace(p), ":")
+		if !ok || src == "" || dst == "" {
+			return nil, fmt.Errorf("invalid type pair %q, want Src:Dst", p)
+		}
+		pairs = append(pairs, pair{src: src, dst: dst})
+	}
+	return pairs, nil
+}
+
+// splitList splits v by commas that are not enclosed in brackets, e.g. Pair[int, string]
+func splitList(v string) []string {
+	var (
+		list  []string
+		depth int
+		start int
+	)
+	for i, r := range v {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				list = append(list, v[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(list, v[start:])
+}
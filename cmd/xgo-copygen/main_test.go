@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const fixtureDir = "internal/fixture"
+
+// fixturePairs reads the type pairs from the go:generate directive of the fixture
+func fixturePairs(t *testing.T) []pair {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join(fixtureDir, "models.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 || fields[0] != "//go:generate" {
+			continue
+		}
+		for i, f := range fields {
+			if f == "-type" && i+1 < len(fields) {
+				pairs, err := parsePairs(fields[i+1])
+				if err != nil {
+					t.Fatal(err)
+				}
+				return pairs
+			}
+		}
+	}
+	t.Fatal("go:generate directive is not found")
+	return nil
+}
+
+func TestGenerate(t *testing.T) {
+	got, err := generate(fixtureDir, defaultOutput, fixturePairs(t))
+	if err != nil {
+		t.Fatalf("should not be error but: %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join(fixtureDir, defaultOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("%s is out of date, run go generate ./...", defaultOutput)
+	}
+}
+
+func TestParsePairs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []pair
+		err  bool
+	}{
+		{
+			name: "pairs",
+			in:   "User:UserDTO, Order:OrderDTO",
+			want: []pair{{"User", "UserDTO"}, {"Order", "OrderDTO"}},
+		},
+		{
+			name: "generic types",
+			in:   "Pair[int, string]:Pair[int64, string]",
+			want: []pair{{"Pair[int, string]", "Pair[int64, string]"}},
+		},
+		{
+			name: "empty",
+			in:   "",
+			err:  true,
+		},
+		{
+			name: "invalid pair",
+			in:   "User",
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parsePairs(tt.in)
+			if tt.err != (err != nil) {
+				t.Fatalf("testing %s: unexpected error: %v", tt.name, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("testing %s: got %v, want %v", tt.name, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("testing %s: got %v, want %v", tt.name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_required(t *testing.T) {
+	_, err := generate(fixtureDir, defaultOutput, []pair{{src: "TagModel", dst: "TagRequiredDTO"}})
+	if err == nil {
+		t.Error("should be error but not")
+	}
+}
+
+func TestGenerate_stringer(t *testing.T) {
+	_, err := generate(fixtureDir, defaultOutput, []pair{{src: "TextMessageModel", dst: "TextQuery"}})
+	if err == nil {
+		t.Error("should be error but not")
+	}
+}
@@ -1,6 +1,9 @@
 package xgo
 
-import "reflect"
+import (
+	"reflect"
+	"time"
+)
 
 // StructToMap converts a struct to map
 func StructToMap(data interface{}) map[string]interface{} {
@@ -18,3 +21,176 @@ func StructToMap(data interface{}) map[string]interface{} {
 	}
 	return result
 }
+
+// structToMap copies a struct to a map that has string keys.
+// When the map element is an interface, nested structs are copied to map[string]interface{}.
+func (c *deepCopier) structToMap(src, dst reflect.Value) error {
+	dstType := dst.Type()
+	elemType := dstType.Elem()
+	fields := getKeyFields(src.Type())
+
+	m := reflect.MakeMapWithSize(dstType, len(fields))
+	for _, f := range fields {
+		fv, err := src.FieldByIndexErr(f.index)
+		if err != nil {
+			// promoted through a nil embedded pointer
+			fv = reflect.Zero(src.Type().FieldByIndex(f.index).Type)
+		}
+		if c.skip(fv) || f.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		var v reflect.Value
+		if elemType.Kind() == reflect.Interface {
+			x := c.opts.toInterface(fv)
+			if x == nil {
+				v = reflect.Zero(elemType)
+			} else {
+				v = reflect.ValueOf(x)
+				if !v.Type().AssignableTo(elemType) {
+					continue
+				}
+			}
+		} else {
+			v = reflect.New(elemType).Elem()
+			c.state.push(fieldStep(f.name))
+			err := c.copyField(fv, v, getConvert(fv.Type(), elemType))
+			c.state.pop()
+			if err != nil {
+				return err
+			}
+		}
+		m.SetMapIndex(reflect.ValueOf(f.key).Convert(dstType.Key()), v)
+	}
+	dst.Set(m)
+	return nil
+}
+
+// mapToStruct copies a map that has string keys to a struct
+func (c *deepCopier) mapToStruct(src, dst reflect.Value) error {
+	keyType := src.Type().Key()
+
+	for _, f := range getKeyFields(dst.Type()) {
+		v := src.MapIndex(reflect.ValueOf(f.key).Convert(keyType))
+		if !v.IsValid() && len(c.opts.matchers) > 0 {
+			v = mapIndexMatch(src, f.key, c.opts.matchers)
+		}
+		if !v.IsValid() {
+			if c.opts.strict || f.required {
+				if err := c.failUnmatched(dst.Type().FieldByIndex(f.index)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				continue
+			}
+			v = v.Elem()
+		}
+		if c.skip(v) || f.omitEmpty && v.IsZero() {
+			continue
+		}
+
+		fv, ok := fieldByIndex(dst, f.index)
+		if !ok {
+			continue
+		}
+		c.state.push(fieldStep(f.name))
+		err := c.copyField(v, fv, getConvert(v.Type(), fv.Type()))
+		c.state.pop()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toInterface returns a copy of the value, structs are copied to map[string]interface{}
+// and the times are normalized with the options InLocation and StripMonotonic
+func (o *options) toInterface(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return o.toInterface(v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return o.normalizeTime(v.Interface().(time.Time))
+		}
+		// the struct without exported fields is copied as it is
+		if len(getKeyFields(v.Type())) == 0 {
+			return v.Interface()
+		}
+		m := map[string]interface{}{}
+		for _, f := range getKeyFields(v.Type()) {
+			fv, err := v.FieldByIndexErr(f.index)
+			if err != nil {
+				m[f.key] = nil
+				continue
+			}
+			if f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			m[f.key] = o.toInterface(fv)
+		}
+		return m
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if !isStructLike(v.Type().Elem()) {
+			s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			reflect.Copy(s, v)
+			if v.Type().Elem() == timeType && o.normalizesTime() {
+				for i := 0; i < s.Len(); i++ {
+					s.Index(i).Set(reflect.ValueOf(o.normalizeTime(s.Index(i).Interface().(time.Time))))
+				}
+			}
+			return s.Interface()
+		}
+		list := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			list[i] = o.toInterface(v.Index(i))
+		}
+		return list
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		if !isStringKeyMap(v.Type()) || !isStructLike(v.Type().Elem()) {
+			m := reflect.MakeMapWithSize(v.Type(), v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				e := iter.Value()
+				if e.Type() == timeType {
+					e = reflect.ValueOf(o.normalizeTime(e.Interface().(time.Time)))
+				}
+				m.SetMapIndex(iter.Key(), e)
+			}
+			return m.Interface()
+		}
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = o.toInterface(iter.Value())
+		}
+		return m
+	}
+	return v.Interface()
+}
+
+// isStringKeyMap determines whether the type is a map that has string keys
+func isStringKeyMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// isStructLike determines whether the type is a struct or a pointer to a struct that has exported fields
+func isStructLike(t reflect.Type) bool {
+	t = indirectType(t)
+	return t.Kind() == reflect.Struct && len(getKeyFields(t)) > 0
+}
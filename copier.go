@@ -4,18 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"time"
+
+	"github.com/glassonion1/xgo/internal/tag"
 )
 
 // tagCopier is tag for deep copy target
-const tagCopier = "copier"
+const tagCopier = tag.Key
 
 type SetCustomField func(src, dst reflect.Value) (bool, error)
 
 // DeepCopy
-func DeepCopy(srcModel interface{}, dstModel interface{}) error {
+func DeepCopy(srcModel interface{}, dstModel interface{}, opts ...Option) error {
 	f := func(src, dst reflect.Value) (bool, error) { return false, nil }
-	return DeepCopyWithCustomSetter(srcModel, dstModel, f)
+	return DeepCopyWithCustomSetter(srcModel, dstModel, f, opts...)
 }
 
 // DeepCopy with custom setter
@@ -23,6 +24,7 @@ func DeepCopyWithCustomSetter(
 	srcModel interface{},
 	dstModel interface{},
 	customSetter SetCustomField,
+	opts ...Option,
 ) error {
 
 	src := reflect.Indirect(reflect.ValueOf(srcModel))
@@ -32,109 +34,391 @@ func DeepCopyWithCustomSetter(
 		return errors.New("copy to value is unaddressable")
 	}
 
-	if src.Kind() == reflect.Slice {
-		if err := copySlice(src, dst); err != nil {
-			return fmt.Errorf("%v", err)
-		}
+	c := newDeepCopier(customSetter, opts)
+	// the references to the source pointer are copied to the destination pointer
+	var root *visit
+	if sv, dv := reflect.ValueOf(srcModel), reflect.ValueOf(dstModel); sv.Kind() == reflect.Ptr && dv.Kind() == reflect.Ptr {
+		root = c.state.visit(sv, dv)
+	}
+	err := c.copy(src, dst)
+	if root != nil {
+		root.done = true
+	}
+	if err != nil {
+		return err
+	}
+	return errors.Join(c.state.errs...)
+}
+
+// deepCopier copies a value with the custom setter and the options through every level of nesting
+type deepCopier struct {
+	setter SetCustomField
+	opts   *options
+	state  *copyState
+}
+
+// copyState is the state of a copy shared with the nested copies
+type copyState struct {
+	opts    options
+	errs    []error
+	path    []pathStep
+	pathBuf [8]pathStep
+	// visited maps the source pointers to the destination pointers
+	visited map[visitKey]*visit
+}
+
+// visitKey is a source pointer and the types, a pointer is copied to another pointer for each destination type
+type visitKey struct {
+	addr     uintptr
+	src, dst reflect.Type
+}
+
+// visit is the destination pointer of a source pointer, done is false while the pointer is being copied
+type visit struct {
+	ptr  reflect.Value
+	done bool
+}
+
+// visit records that the source pointer is copied to the destination pointer
+func (s *copyState) visit(src, dst reflect.Value) *visit {
+	if s.visited == nil {
+		s.visited = map[visitKey]*visit{}
+	}
+	v := &visit{ptr: dst}
+	s.visited[visitKey{src.Pointer(), src.Type(), dst.Type()}] = v
+	return v
+}
+
+func newDeepCopier(customSetter SetCustomField, opts []Option) *deepCopier {
+	state := &copyState{opts: newOptions(opts)}
+	state.path = state.pathBuf[:0]
+	return &deepCopier{setter: customSetter, opts: &state.opts, state: state}
+}
+
+// fail makes the CopyError of the current path, it returns nil when the errors are collected
+func (c *deepCopier) fail(src, dst reflect.Type, err error) error {
+	e := &CopyError{Path: c.state.pathString(), SrcType: src, DstType: dst, Err: err}
+	if c.opts.collectErrors {
+		c.state.errs = append(c.state.errs, e)
 		return nil
 	}
+	return e
+}
+
+// copy copies src to dst, both of them are not pointers
+func (c *deepCopier) copy(src, dst reflect.Value) error {
+	if !src.IsValid() {
+		return nil
+	}
+	if c.opts.maxDepth >= 0 && len(c.state.path) > c.opts.maxDepth {
+		return c.fail(src.Type(), dst.Type(), errMaxDepth)
+	}
 
-	// What to do if the deepcopy destination model has a tag
-	var srcToDstTagMap = map[string]string{}
-	for i := 0; i < dst.NumField(); i++ {
-		dstF := dst.Type().Field(i)
-		if tag, ok := dstF.Tag.Lookup(tagCopier); ok {
-			srcToDstTagMap[tag] = dstF.Name
+	// the converters take priority over the built-in copies of the root values too
+	isSet, err := c.convertCustom(src, dst)
+	if !isSet && err == nil {
+		if conv, ok := lookupConverter(src.Type(), dst.Type()); ok {
+			isSet, err = conv(src, dst)
 		}
 	}
+	if err != nil {
+		return c.fail(src.Type(), dst.Type(), err)
+	}
+	if isSet {
+		return nil
+	}
 
-	for i := 0; i < src.NumField(); i++ {
-		field := src.Type().Field(i)
-		srcFieldValue := src.FieldByName(field.Name)
+	switch src.Kind() {
+	case reflect.Slice, reflect.Array:
+		return c.copySlice(src, dst)
+	case reflect.Map:
+		switch {
+		case dst.Kind() == reflect.Map:
+			return c.copyMap(src, dst)
+		case dst.Kind() == reflect.Struct && isStringKeyMap(src.Type()):
+			return c.mapToStruct(src, dst)
+		}
+	case reflect.Struct:
+		switch {
+		case dst.Kind() == reflect.Map && isStringKeyMap(dst.Type()):
+			return c.structToMap(src, dst)
+		case dst.Kind() == reflect.Struct && mergeable(dst.Type()):
+			return c.copyStruct(src, dst)
+		}
+	}
 
-		dstFieldName := field.Name
-		if tag, ok := field.Tag.Lookup(tagCopier); ok {
-			dstFieldName = tag
+	if isSet, err := c.convertTime(src, dst); err != nil || isSet {
+		return err
+	}
+	// the value that a pointer refers to, e.g. *int
+	if conv := getConvert(src.Type(), dst.Type()); conv != nil {
+		isSet, err := conv(src, dst)
+		if err != nil {
+			return c.fail(src.Type(), dst.Type(), err)
 		}
-		if tag, ok := srcToDstTagMap[field.Name]; ok {
-			dstFieldName = tag
+		if isSet {
+			return nil
 		}
+	}
+	// a struct without exported fields like time.Time has no field to copy to
+	if src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct {
+		return nil
+	}
+	return c.fail(src.Type(), dst.Type(), errUnsupported)
+}
 
-		_, ok := dst.Type().FieldByName(dstFieldName)
-		dstFieldValue := dst.FieldByName(dstFieldName)
+func (c *deepCopier) copyStruct(src, dst reflect.Value) error {
+	plan := getPlan(src.Type(), dst.Type(), c.opts)
+	for _, f := range plan.fields {
+		srcFieldValue, err := src.FieldByIndexErr(f.srcIndex)
+		if err != nil || f.required && srcFieldValue.IsZero() {
+			if f.required {
+				if err := c.failRequired(f, src.Type(), dst.Type()); err != nil {
+					return err
+				}
+			}
+			// promoted through a nil embedded pointer
+			continue
+		}
+		if c.skip(srcFieldValue) || f.omitEmpty && srcFieldValue.IsZero() {
+			continue
+		}
+		// the embedded pointer is allocated only when there is a value to copy
+		if f.dstIndirect && srcFieldValue.IsZero() {
+			continue
+		}
+		dstFieldValue, ok := fieldByIndex(dst, f.dstIndex)
 		if !ok {
 			continue
 		}
-
-		// Ignores private field
-		if !IsFirstUpper(dstFieldName) {
+		if f.shallow && srcFieldValue.Type().AssignableTo(dstFieldValue.Type()) {
+			dstFieldValue.Set(srcFieldValue)
 			continue
 		}
 
-		isSet, err := convert(srcFieldValue, dstFieldValue)
+		c.state.push(fieldStep(f.name))
+		if f.epoch != "" {
+			restore := c.withEpochUnit(epochUnits[f.epoch])
+			err = c.copyField(srcFieldValue, dstFieldValue, f.convert)
+			restore()
+		} else {
+			err = c.copyField(srcFieldValue, dstFieldValue, f.convert)
+		}
+		c.state.pop()
 		if err != nil {
-			return fmt.Errorf("%s: %v", field.Name, err)
+			return err
 		}
-		if isSet {
-			continue
+	}
+
+	unmatched := plan.missing
+	if c.opts.strict {
+		unmatched = plan.unmatched
+	}
+	for _, f := range unmatched {
+		if err := c.failUnmatched(f); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		isSet, err = customSetter(srcFieldValue, dstFieldValue)
-		if err != nil {
-			return fmt.Errorf("%s: %v", field.Name, err)
+// failRequired makes the CopyError of the source field that the copier tag requires but has the zero value
+func (c *deepCopier) failRequired(f fieldPlan, src, dst reflect.Type) error {
+	c.state.push(fieldStep(f.name))
+	err := c.fail(src.FieldByIndex(f.srcIndex).Type, dst.FieldByIndex(f.dstIndex).Type, errRequired)
+	c.state.pop()
+	return err
+}
+
+// failUnmatched makes the CopyError of the destination field that no source field is copied to
+func (c *deepCopier) failUnmatched(f reflect.StructField) error {
+	c.state.push(fieldStep(f.Name))
+	err := c.fail(nil, f.Type, errUnmatched)
+	c.state.pop()
+	return err
+}
+
+// copyField copies a field value with the conversion chain, conv is the conversion chosen for the types
+func (c *deepCopier) copyField(
+	srcFieldValue reflect.Value,
+	dstFieldValue reflect.Value,
+	conv convertFunc,
+) error {
+
+	// the dynamic value of an interface
+	if srcFieldValue.Kind() == reflect.Interface && dstFieldValue.Kind() != reflect.Interface {
+		if srcFieldValue.IsNil() {
+			return nil
 		}
-		if isSet {
-			continue
+		srcFieldValue = srcFieldValue.Elem()
+		conv = getConvert(srcFieldValue.Type(), dstFieldValue.Type())
+	}
+
+	isSet, err := c.convertValue(srcFieldValue, dstFieldValue, conv)
+	if err != nil || isSet {
+		return err
+	}
+
+	// struct, pointer, slice, map
+	switch srcFieldValue.Kind() {
+	case reflect.Struct:
+		return c.instantiate(srcFieldValue, dstFieldValue)
+	case reflect.Ptr:
+
+		if srcFieldValue.IsNil() {
+			return nil
+		}
+		// copy to indirect
+		indirect := reflect.Indirect(srcFieldValue)
+		if indirect.Type().AssignableTo(dstFieldValue.Type()) && dstFieldValue.Type().Kind() != reflect.Ptr &&
+			!c.opts.merges(indirect.Type(), dstFieldValue.Type()) {
+			dstFieldValue.Set(indirect)
+			return nil
+		}
+
+		return c.instantiate(srcFieldValue, dstFieldValue)
+	case reflect.Slice, reflect.Array:
+		return c.copySlice(srcFieldValue, dstFieldValue)
+	case reflect.Map:
+		switch indirectType(dstFieldValue.Type()).Kind() {
+		case reflect.Map:
+			return c.copyMap(srcFieldValue, dstFieldValue)
+		case reflect.Struct:
+			// map to struct
+			if srcFieldValue.IsNil() {
+				return nil
+			}
+			return c.instantiate(srcFieldValue, dstFieldValue)
 		}
+	}
 
-		// set the time.Time field
-		isSet, err = setTimeField(srcFieldValue, dstFieldValue)
+	return nil
+}
+
+// convertValue copies src to dst with the converters, the conversion chosen for the types,
+// the custom setter and the time conversion, in this order.
+// It reports whether the value is handled, the returned error is a CopyError.
+func (c *deepCopier) convertValue(src, dst reflect.Value, conv convertFunc) (bool, error) {
+	isSet, err := c.convertCustom(src, dst)
+	if err != nil {
+		return true, c.fail(src.Type(), dst.Type(), err)
+	}
+	if isSet {
+		return true, nil
+	}
+
+	if dst.Kind() == reflect.Interface {
+		return true, c.copyInterface(src, dst)
+	}
+
+	// the numeric conversion modes take priority over the Go conversions
+	if isSet, err := c.convertNumber(src, dst); err != nil || isSet {
+		return isSet, err
+	}
+
+	// the times are normalized instead of being assigned as they are
+	if isSet, err := c.convertTime(src, dst); err != nil || isSet {
+		return isSet, err
+	}
+
+	if conv != nil && !c.opts.merges(src.Type(), dst.Type()) {
+		isSet, err := conv(src, dst)
 		if err != nil {
-			return fmt.Errorf("%s: %v", field.Name, err)
+			return true, c.fail(src.Type(), dst.Type(), err)
 		}
 		if isSet {
-			continue
+			return true, nil
 		}
+	}
 
-		// struct, pointer, slice
-		switch srcFieldValue.Kind() {
-		case reflect.Struct:
-			if !field.Anonymous {
-				dv, vFunc := instantiate(dstFieldValue)
-				if err := DeepCopy(srcFieldValue.Interface(), dv.Interface()); err != nil {
-					return fmt.Errorf("%s: %v", field.Name, err)
-				}
-				dstFieldValue.Set(vFunc())
-				continue
-			}
-			dstFieldValue.SetInt(srcFieldValue.Int())
-		case reflect.Ptr:
+	isSet, err = c.setter(src, dst)
+	if err != nil {
+		return true, c.fail(src.Type(), dst.Type(), err)
+	}
+	if isSet {
+		return true, nil
+	}
 
-			if srcFieldValue.IsNil() {
-				continue
-			}
-			// copy to indirect
-			indirect := reflect.Indirect(srcFieldValue)
-			if indirect.Type().AssignableTo(dstFieldValue.Type()) && dstFieldValue.Type().Kind() != reflect.Ptr {
-				dstFieldValue.Set(indirect)
-				continue
-			}
+	isSet, err = c.setEpoch(src, dst)
+	if err != nil {
+		return true, c.fail(src.Type(), dst.Type(), err)
+	}
+	if isSet {
+		return true, nil
+	}
 
-			dv, vFunc := instantiate(dstFieldValue)
-			if err := DeepCopy(srcFieldValue.Interface(), dv.Interface()); err != nil {
-				return fmt.Errorf("%s: %v", field.Name, err)
-			}
-			dstFieldValue.Set(vFunc())
-			continue
-		case reflect.Slice:
-			if err := copySlice(srcFieldValue, dstFieldValue); err != nil {
-				return fmt.Errorf("%s: %v", field.Name, err)
-			}
-			continue
+	// set the time.Time field
+	isSet, err = setTimeField(src, dst, c.opts)
+	if err != nil {
+		return true, c.fail(src.Type(), dst.Type(), err)
+	}
+	if isSet {
+		return true, nil
+	}
+
+	return c.convertText(src, dst)
+}
+
+// instantiate copies src to a new value and sets it to dst
+func (c *deepCopier) instantiate(src, dst reflect.Value) error {
+	if src.Kind() == reflect.Ptr && dst.Kind() == reflect.Ptr {
+		return c.copyPointer(src, dst)
+	}
+	// Merge copies to the existing value
+	if c.opts.merge {
+		switch {
+		case dst.Kind() == reflect.Struct:
+			return c.copy(reflect.Indirect(src), dst)
+		case dst.Kind() == reflect.Ptr && !dst.IsNil():
+			return c.copy(reflect.Indirect(src), dst.Elem())
 		}
+	}
 
+	dv, vFunc := instantiate(dst)
+	if err := c.copy(reflect.Indirect(src), dv.Elem()); err != nil {
+		return err
 	}
+	dst.Set(vFunc())
+	return nil
+}
 
+// convertCustom copies the value with the converter of WithConverter for the type pair
+func (c *deepCopier) convertCustom(src, dst reflect.Value) (bool, error) {
+	if len(c.opts.converters) == 0 {
+		return false, nil
+	}
+	conv, ok := c.opts.converters[typePair{src.Type(), dst.Type()}]
+	if !ok {
+		return false, nil
+	}
+	return conv.convert(src, dst)
+}
+
+// copyPointer copies a pointer, the same source pointer is copied to the same destination pointer
+// so that the shared pointers and the cycles are preserved
+func (c *deepCopier) copyPointer(src, dst reflect.Value) error {
+	if src.IsNil() {
+		return nil
+	}
+	if v, ok := c.state.visited[visitKey{src.Pointer(), src.Type(), dst.Type()}]; ok {
+		if !v.done && c.opts.errorOnCycle {
+			return c.fail(src.Type(), dst.Type(), errCycle)
+		}
+		dst.Set(v.ptr)
+		return nil
+	}
+
+	rv := dst
+	if !c.opts.merge || dst.IsNil() {
+		rv = reflect.New(dst.Type().Elem())
+	}
+	v := c.state.visit(src, rv)
+	err := c.copy(src.Elem(), rv.Elem())
+	v.done = true
+	if err != nil {
+		return err
+	}
+	dst.Set(rv)
 	return nil
 }
 
@@ -153,190 +437,161 @@ func instantiate(v reflect.Value) (reflect.Value, func() reflect.Value) {
 	return rv, vFunc
 }
 
-func convert(src, dst reflect.Value) (bool, error) {
-
-	// the source and destination types are the same
-	if src.Type().ConvertibleTo(dst.Type()) {
-		dst.Set(src.Convert(dst.Type()))
-		return true, nil
+// copySlice copies the elements of a slice or an array to a slice or an array.
+// The elements that do not fit in the destination array are truncated unless StrictLength is set.
+func (c *deepCopier) copySlice(src, dst reflect.Value) error {
+	if src.Kind() == reflect.Slice && src.IsNil() {
+		return nil
 	}
 
-	// from pointer type to non pointer type
-	if src.Type().Kind() == reflect.Ptr {
-		if src.IsNil() {
-			return true, nil
+	n := src.Len()
+	switch dst.Kind() {
+	case reflect.Slice:
+		if c.opts.merge && c.opts.slicePolicy.kind != sliceReplace {
+			return c.mergeSlice(src, dst)
 		}
-
-		if src.Type().Elem().ConvertibleTo(dst.Type()) {
-			dst.Set(src.Elem().Convert(dst.Type()))
-			return true, nil
+		capacity := n
+		if src.Kind() == reflect.Slice {
+			capacity = src.Cap()
 		}
-		if dst.Type().Kind() == reflect.Ptr {
-			if src.Type().Elem().ConvertibleTo(dst.Type().Elem()) {
-				rv := reflect.New(dst.Type().Elem())
-				rv.Elem().Set(src.Elem().Convert(dst.Type().Elem()))
-				dst.Set(rv)
-				return true, nil
-			}
+		dst.Set(reflect.MakeSlice(reflect.SliceOf(dst.Type().Elem()), n, capacity))
+	case reflect.Array:
+		if n != dst.Len() && c.opts.strictLength {
+			return c.fail(src.Type(), dst.Type(), fmt.Errorf("length %d does not match the array length %d", n, dst.Len()))
 		}
+		dst.Set(reflect.Zero(dst.Type()))
+		n = min(n, dst.Len())
+	default:
+		return c.fail(src.Type(), dst.Type(), errUnsupported)
 	}
 
-	// from non pointer type to pointer type
-	if dst.Type().Kind() == reflect.Ptr {
-		if src.Type().ConvertibleTo(dst.Type().Elem()) {
-			rv := reflect.New(dst.Type().Elem())
-			rv.Elem().Set(src.Convert(dst.Type().Elem()))
-			dst.Set(rv)
-			return true, nil
+	conv := getConvert(src.Type().Elem(), dst.Type().Elem())
+	for i := 0; i < n; i++ {
+		c.state.push(indexStep(i))
+		err := c.copyElem(src.Index(i), dst.Index(i), conv)
+		c.state.pop()
+		if err != nil {
+			return err
 		}
 	}
-
-	return false, nil
+	return nil
 }
 
-func copySlice(src, dst reflect.Value) error {
+// copyMap copies the keys and the elements of a map, a nil map is not copied
+func (c *deepCopier) copyMap(src, dst reflect.Value) error {
 	if src.IsNil() {
 		return nil
 	}
-	slice := reflect.MakeSlice(reflect.SliceOf(dst.Type().Elem()), src.Len(), src.Cap())
-	dst.Set(slice)
-
-	for i := 0; i < src.Len(); i++ {
-		d := dst.Index(i)
+	dstType := dst.Type()
+	m := dst
+	if !c.opts.merge || dst.IsNil() {
+		m = reflect.MakeMapWithSize(dstType, src.Len())
+	}
 
-		isSet, err := convert(src.Index(i), d)
-		if err != nil {
-			return err
+	keyConv := getConvert(src.Type().Key(), dstType.Key())
+	elemConv := getConvert(src.Type().Elem(), dstType.Elem())
+	iter := src.MapRange()
+	for iter.Next() {
+		c.state.push(keyStep(iter.Key()))
+		k := reflect.New(dstType.Key()).Elem()
+		err := c.copyElem(iter.Key(), k, keyConv)
+		v := reflect.New(dstType.Elem()).Elem()
+		if c.opts.merge && err == nil {
+			// the existing element is merged
+			if e := m.MapIndex(k); e.IsValid() {
+				v.Set(e)
+			}
 		}
-		if isSet {
-			continue
+		if err == nil {
+			err = c.copyElem(iter.Value(), v, elemConv)
 		}
-
-		// pointer or struct
-		dv, vFunc := instantiate(d)
-		if err := DeepCopy(src.Index(i).Interface(), dv.Interface()); err != nil {
+		c.state.pop()
+		if err != nil {
 			return err
 		}
-		d.Set(vFunc())
+		m.SetMapIndex(k, v)
 	}
+	dst.Set(m)
 	return nil
 }
 
-func setTimeField(src, dst reflect.Value) (bool, error) {
-	const format = time.RFC3339Nano
-
-	switch t := src.Interface().(type) {
-	case time.Time:
-		// time.Time -> int64
-		switch dst.Interface().(type) {
-		case int64:
-			dst.Set(reflect.ValueOf(t.UnixNano()))
-			return true, nil
-		case *int64:
-			rv := reflect.New(dst.Type().Elem())
-			rv.Elem().Set(reflect.ValueOf(t.UnixNano()))
-			dst.Set(rv)
-			return true, nil
-		case string:
-			dst.Set(reflect.ValueOf(t.Format(format)))
-			return true, nil
-		case *string:
-			rv := reflect.New(dst.Type().Elem())
-			rv.Elem().Set(reflect.ValueOf(t.Format(format)))
-			dst.Set(rv)
-			return true, nil
+// copyElem copies an element of a slice or a map
+func (c *deepCopier) copyElem(src, dst reflect.Value, conv convertFunc) error {
+	// the dynamic value of an interface, e.g. an element of []interface{}
+	if src.Kind() == reflect.Interface && dst.Kind() != reflect.Interface {
+		if src.IsNil() {
+			return nil
 		}
+		src = src.Elem()
+		conv = getConvert(src.Type(), dst.Type())
+	}
 
-	case *time.Time:
-		if t == nil {
-			return true, nil
-		}
-		// *time.Time -> int64 or *int64
-		switch dst.Interface().(type) {
-		case int64:
-			dst.Set(reflect.ValueOf(t.UnixNano()))
-			return true, nil
-		case *int64:
-			rv := reflect.New(dst.Type().Elem())
-			rv.Elem().Set(reflect.ValueOf(t.UnixNano()))
-			dst.Set(rv)
-			return true, nil
-		case string:
-			dst.Set(reflect.ValueOf(t.Format(format)))
-			return true, nil
-		case *string:
-			rv := reflect.New(dst.Type().Elem())
-			rv.Elem().Set(reflect.ValueOf(t.Format(format)))
-			dst.Set(rv)
-			return true, nil
-		}
+	isSet, err := c.convertValue(src, dst, conv)
+	if err != nil || isSet {
+		return err
+	}
 
-	case int64:
-		// int64 -> time.Time or *time.Time
-		switch dst.Interface().(type) {
-		case time.Time:
-			dst.Set(reflect.ValueOf(time.Unix(0, t)))
-			return true, nil
-		case *time.Time:
-			rv := reflect.New(dst.Type().Elem())
-			rv.Elem().Set(reflect.ValueOf(time.Unix(0, t)))
-			dst.Set(rv)
-			return true, nil
-		}
+	// pointer, struct, slice or map
+	return c.instantiate(src, dst)
+}
 
-	case *int64:
-		if t == nil {
-			return true, nil
-		}
-		// *int64 -> time.Time or *time.Time
-		switch dst.Interface().(type) {
-		case time.Time:
-			reflect.Indirect(dst).Set(reflect.ValueOf(time.Unix(0, *t)))
-			return true, nil
-		case *time.Time:
-			rv := reflect.New(dst.Type().Elem())
-			rv.Elem().Set(reflect.ValueOf(time.Unix(0, *t)))
-			dst.Set(rv)
-			return true, nil
+// copyInterface copies the dynamic value of src to a new value of the concrete type, and sets it to the interface dst.
+// The concrete type is the type of the dynamic value unless WithConcreteType picks another one.
+func (c *deepCopier) copyInterface(src, dst reflect.Value) error {
+	// the converter of RegisterConverter
+	if conv, ok := lookupConverter(src.Type(), dst.Type()); ok {
+		if _, err := conv(src, dst); err != nil {
+			return c.fail(src.Type(), dst.Type(), err)
 		}
+		return nil
+	}
 
-	case string:
-		// string -> time.Time or *time.Time
-		v, err := time.Parse(format, t)
-		if err != nil {
-			return true, nil
+	if src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return nil
 		}
+		src = src.Elem()
+	}
 
-		switch dst.Interface().(type) {
-		case time.Time:
-			dst.Set(reflect.ValueOf(v))
-			return true, nil
-		case *time.Time:
-			rv := reflect.New(dst.Type().Elem())
-			rv.Elem().Set(reflect.ValueOf(v))
-			dst.Set(rv)
-			return true, nil
+	concrete := src.Type()
+	if c.opts.concreteType != nil {
+		if t := c.opts.concreteType(src.Type(), dst.Type()); t != nil {
+			concrete = t
 		}
+	}
+	if !concrete.AssignableTo(dst.Type()) {
+		return c.fail(src.Type(), dst.Type(), fmt.Errorf("%v does not implement %v", concrete, dst.Type()))
+	}
 
-	case *string:
-		if t == nil {
-			return true, nil
-		}
-		// *string -> time.Time or *time.Time
-		v, err := time.Parse(format, *t)
-		if err != nil {
-			return true, nil
-		}
-		switch dst.Interface().(type) {
-		case time.Time:
-			reflect.Indirect(dst).Set(reflect.ValueOf(v))
-			return true, nil
-		case *time.Time:
-			rv := reflect.New(dst.Type().Elem())
-			rv.Elem().Set(reflect.ValueOf(v))
-			dst.Set(rv)
-			return true, nil
+	v := reflect.New(concrete).Elem()
+	var err error
+	switch {
+	case concrete == src.Type() && hasUnexportedField(concrete):
+		// copied like a field of the struct type, a new value would lose the unexported fields
+		err = c.copyField(src, v, getConvert(src.Type(), concrete))
+	case src.Kind() == reflect.Ptr, src.Kind() == reflect.Struct, src.Kind() == reflect.Slice,
+		src.Kind() == reflect.Array, src.Kind() == reflect.Map:
+		// the concrete value is copied, not shared
+		err = c.instantiate(src, v)
+	default:
+		err = c.copyField(src, v, getConvert(src.Type(), concrete))
+	}
+	if err != nil {
+		return err
+	}
+	dst.Set(v)
+	return nil
+}
+
+// hasUnexportedField determines whether the type is a struct that has an unexported field
+func hasUnexportedField(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			return true
 		}
 	}
-	return false, nil
+	return false
 }
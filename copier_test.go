@@ -1,7 +1,11 @@
 package xgo_test
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -688,6 +692,1884 @@ func TestDeepCopy_ptr_slice(t *testing.T) {
 	}
 }
 
+func TestDeepCopy_map(t *testing.T) {
+	type Key string
+	type SrcItem struct {
+		Name  string
+		Price int32
+	}
+	type DstItem struct {
+		Name  string
+		Price int64
+	}
+	type Example1 struct {
+		Items    map[string]SrcItem
+		PtrItems map[string]*SrcItem
+		Keys     map[Key]int32
+		Lists    map[string][]SrcItem
+		Nested   map[string]map[string]SrcItem
+		Nil      map[string]SrcItem
+	}
+	type Example2 struct {
+		Items    map[string]DstItem
+		PtrItems map[string]DstItem
+		Keys     map[string]int64
+		Lists    map[string][]*DstItem
+		Nested   map[string]map[string]DstItem
+		Nil      map[string]DstItem
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+	}
+
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+		err  bool
+	}{
+		{
+			name: "map fields",
+			in: args{
+				src: Example1{
+					Items:    map[string]SrcItem{"foo": {Name: "foo", Price: 100}},
+					PtrItems: map[string]*SrcItem{"foo": {Name: "foo", Price: 100}, "nil": nil},
+					Keys:     map[Key]int32{"foo": 100, "bar": 200},
+					Lists:    map[string][]SrcItem{"foo": {{Name: "foo", Price: 100}, {Name: "bar", Price: 200}}},
+					Nested:   map[string]map[string]SrcItem{"foo": {"bar": {Name: "bar", Price: 200}}},
+				},
+				dest: &Example2{},
+			},
+			want: &Example2{
+				Items:    map[string]DstItem{"foo": {Name: "foo", Price: 100}},
+				PtrItems: map[string]DstItem{"foo": {Name: "foo", Price: 100}, "nil": {}},
+				Keys:     map[string]int64{"foo": 100, "bar": 200},
+				Lists:    map[string][]*DstItem{"foo": {{Name: "foo", Price: 100}, {Name: "bar", Price: 200}}},
+				Nested:   map[string]map[string]DstItem{"foo": {"bar": {Name: "bar", Price: 200}}},
+				Nil:      nil,
+			},
+		},
+		{
+			name: "map to map",
+			in: args{
+				src:  map[Key]SrcItem{"foo": {Name: "foo", Price: 100}},
+				dest: &map[string]*DstItem{},
+			},
+			want: &map[string]*DstItem{"foo": {Name: "foo", Price: 100}},
+		},
+		{
+			name: "nil map to map",
+			in: args{
+				src:  map[string]SrcItem(nil),
+				dest: &map[string]DstItem{},
+			},
+			want: &map[string]DstItem{},
+		},
+		{
+			name: "map with int keys to struct",
+			in: args{
+				src:  map[int]SrcItem{1: {Name: "foo", Price: 100}},
+				dest: &DstItem{},
+			},
+			want: &DstItem{},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.in.src, tt.in.dest)
+			got := tt.in.dest
+			if !tt.err && err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			if tt.err && err == nil {
+				t.Errorf("testing %s: should be error for %#v but not", tt.name, tt.in)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopy_structMap(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  string `copier:"zip"`
+	}
+	type Item struct {
+		Name  string
+		Price int64
+	}
+	type User struct {
+		ID        string `copier:"id"`
+		Age       int
+		CreatedAt time.Time
+		Address   Address
+		Office    *Address
+		Home      *Address
+		Items     []Item
+		Tags      []string
+		private   string
+	}
+	type Hash struct {
+		ID        string `copier:"id"`
+		Name      *string
+		CreatedAt time.Time
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	const format = time.RFC3339Nano
+
+	user := User{
+		ID:        "xxxx",
+		Age:       33,
+		CreatedAt: now,
+		Address:   Address{City: "Tokyo", Zip: "100-0001"},
+		Office:    &Address{City: "Osaka", Zip: "530-0001"},
+		Items:     []Item{{Name: "foo", Price: 100}},
+		Tags:      []string{"foo", "bar"},
+		private:   "private",
+	}
+	userMap := map[string]interface{}{
+		"id":        "xxxx",
+		"Age":       33,
+		"CreatedAt": now,
+		"Address":   map[string]interface{}{"City": "Tokyo", "zip": "100-0001"},
+		"Office":    map[string]interface{}{"City": "Osaka", "zip": "530-0001"},
+		"Home":      nil,
+		"Items":     []interface{}{map[string]interface{}{"Name": "foo", "Price": int64(100)}},
+		"Tags":      []string{"foo", "bar"},
+	}
+
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+	}{
+		{
+			name: "struct to map[string]interface{}",
+			in: args{
+				src:  user,
+				dest: &map[string]interface{}{},
+			},
+			want: &userMap,
+		},
+		{
+			name: "struct to map[string]string",
+			in: args{
+				src:  Hash{ID: "xxxx", Name: xgo.ToPtr("R2D2"), CreatedAt: now},
+				dest: &map[string]string{},
+			},
+			want: &map[string]string{
+				"id":        "xxxx",
+				"Name":      "R2D2",
+				"CreatedAt": now.Format(format),
+			},
+		},
+		{
+			name: "map[string]interface{} to struct",
+			in: args{
+				src: map[string]interface{}{
+					"id":        "xxxx",
+					"Age":       float64(33),
+					"CreatedAt": now.Format(format),
+					"Address":   map[string]interface{}{"City": "Tokyo", "zip": "100-0001"},
+					"Office":    map[string]interface{}{"City": "Osaka", "zip": "530-0001"},
+					"Home":      nil,
+					"Items":     []interface{}{map[string]interface{}{"Name": "foo", "Price": float64(100)}},
+					"Tags":      []interface{}{"foo", "bar"},
+					"private":   "private",
+					"Unknown":   "unknown",
+				},
+				dest: &User{},
+			},
+			want: &User{
+				ID:        "xxxx",
+				Age:       33,
+				CreatedAt: now,
+				Address:   Address{City: "Tokyo", Zip: "100-0001"},
+				Office:    &Address{City: "Osaka", Zip: "530-0001"},
+				Items:     []Item{{Name: "foo", Price: 100}},
+				Tags:      []string{"foo", "bar"},
+			},
+		},
+		{
+			name: "map[string]string to struct",
+			in: args{
+				src: map[string]string{
+					"id":        "xxxx",
+					"Name":      "R2D2",
+					"CreatedAt": now.Format(format),
+				},
+				dest: &Hash{},
+			},
+			want: &Hash{ID: "xxxx", Name: xgo.ToPtr("R2D2"), CreatedAt: now},
+		},
+		{
+			name: "map to struct round trip",
+			in: args{
+				src:  userMap,
+				dest: &User{},
+			},
+			want: &User{
+				ID:        user.ID,
+				Age:       user.Age,
+				CreatedAt: user.CreatedAt,
+				Address:   user.Address,
+				Office:    user.Office,
+				Items:     user.Items,
+				Tags:      user.Tags,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.in.src, tt.in.dest)
+			got := tt.in.dest
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(User{})); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopy_embedded(t *testing.T) {
+	type BaseModel struct {
+		ID        string
+		CreatedAt time.Time
+	}
+	type BaseDTO struct {
+		ID        string
+		CreatedAt int64
+	}
+	type Embedded struct {
+		BaseModel
+		Name string
+	}
+	type EmbeddedPtr struct {
+		*BaseModel
+		Name string
+	}
+	type EmbeddedDTO struct {
+		BaseDTO
+		Name string
+	}
+	type EmbeddedPtrDTO struct {
+		*BaseDTO
+		Name string
+	}
+	type Flat struct {
+		ID        string
+		CreatedAt time.Time
+		Name      string
+	}
+	type Named struct {
+		BaseModel BaseDTO
+		Name      string
+	}
+	type Named2 struct {
+		Base BaseModel `copier:"BaseModel"`
+	}
+
+	// Name of Shadowed is shallower than Name of Embedded, ID of Ambiguous is ambiguous
+	type Shadowed struct {
+		Embedded
+		Name string
+	}
+	type Other struct {
+		ID string
+	}
+	type Ambiguous struct {
+		BaseModel
+		Other
+		Name string
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+	}{
+		{
+			name: "embedded to flat",
+			in: args{
+				src:  Embedded{BaseModel: BaseModel{ID: "id", CreatedAt: now}, Name: "R2D2"},
+				dest: &Flat{},
+			},
+			want: &Flat{ID: "id", CreatedAt: now, Name: "R2D2"},
+		},
+		{
+			name: "flat to embedded",
+			in: args{
+				src:  Flat{ID: "id", CreatedAt: now, Name: "R2D2"},
+				dest: &Embedded{},
+			},
+			want: &Embedded{BaseModel: BaseModel{ID: "id", CreatedAt: now}, Name: "R2D2"},
+		},
+		{
+			name: "flat to embedded pointer",
+			in: args{
+				src:  Flat{ID: "id", CreatedAt: now, Name: "R2D2"},
+				dest: &EmbeddedPtr{},
+			},
+			want: &EmbeddedPtr{BaseModel: &BaseModel{ID: "id", CreatedAt: now}, Name: "R2D2"},
+		},
+		{
+			name: "flat zero value to embedded pointer",
+			in: args{
+				src:  Flat{Name: "R2D2"},
+				dest: &EmbeddedPtr{},
+			},
+			want: &EmbeddedPtr{Name: "R2D2"},
+		},
+		{
+			name: "embedded to embedded",
+			in: args{
+				src:  Embedded{BaseModel: BaseModel{ID: "id", CreatedAt: now}, Name: "R2D2"},
+				dest: &EmbeddedPtrDTO{},
+			},
+			want: &EmbeddedPtrDTO{BaseDTO: &BaseDTO{ID: "id", CreatedAt: now.UnixNano()}, Name: "R2D2"},
+		},
+		{
+			name: "nil embedded pointer to embedded",
+			in: args{
+				src:  EmbeddedPtr{Name: "R2D2"},
+				dest: &EmbeddedDTO{},
+			},
+			want: &EmbeddedDTO{Name: "R2D2"},
+		},
+		{
+			name: "embedded to named field",
+			in: args{
+				src:  Embedded{BaseModel: BaseModel{ID: "id", CreatedAt: now}, Name: "R2D2"},
+				dest: &Named{},
+			},
+			want: &Named{BaseModel: BaseDTO{ID: "id", CreatedAt: now.UnixNano()}, Name: "R2D2"},
+		},
+		{
+			name: "embedded to tagged field",
+			in: args{
+				src:  Embedded{BaseModel: BaseModel{ID: "id", CreatedAt: now}, Name: "R2D2"},
+				dest: &Named2{},
+			},
+			want: &Named2{Base: BaseModel{ID: "id", CreatedAt: now}},
+		},
+		{
+			name: "shallower field wins",
+			in: args{
+				src: Shadowed{
+					Embedded: Embedded{BaseModel: BaseModel{ID: "id"}, Name: "deep"},
+					Name:     "shallow",
+				},
+				dest: &Flat{},
+			},
+			want: &Flat{ID: "id", Name: "shallow"},
+		},
+		{
+			name: "ambiguous field is not copied",
+			in: args{
+				src: Ambiguous{
+					BaseModel: BaseModel{ID: "id1", CreatedAt: now},
+					Other:     Other{ID: "id2"},
+					Name:      "R2D2",
+				},
+				dest: &Flat{},
+			},
+			want: &Flat{CreatedAt: now, Name: "R2D2"},
+		},
+		{
+			name: "embedded to map",
+			in: args{
+				src:  Embedded{BaseModel: BaseModel{ID: "id", CreatedAt: now}, Name: "R2D2"},
+				dest: &map[string]interface{}{},
+			},
+			want: &map[string]interface{}{"ID": "id", "CreatedAt": now, "Name": "R2D2"},
+		},
+		{
+			name: "map to embedded pointer",
+			in: args{
+				src:  map[string]interface{}{"ID": "id", "Name": "R2D2"},
+				dest: &EmbeddedPtr{},
+			},
+			want: &EmbeddedPtr{BaseModel: &BaseModel{ID: "id"}, Name: "R2D2"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.in.src, tt.in.dest)
+			got := tt.in.dest
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopy_error(t *testing.T) {
+	type Money struct {
+		Amount int64
+	}
+	type Item struct {
+		Name  string
+		Price Money
+	}
+	type ItemDTO struct {
+		Name  string
+		Price int64
+	}
+	type Order struct {
+		Items map[string]Item
+	}
+	type OrderDTO struct {
+		Items map[string]ItemDTO
+	}
+	type Customer struct {
+		Rank   string
+		Orders []Order
+	}
+	type CustomerDTO struct {
+		Rank   int
+		Orders []OrderDTO
+	}
+
+	errInvalid := errors.New("invalid")
+	setter := func(src, dst reflect.Value) (bool, error) {
+		if src.Kind() != reflect.String || dst.Kind() != reflect.Int {
+			return false, nil
+		}
+		i, err := strconv.Atoi(src.String())
+		if err != nil {
+			return false, errInvalid
+		}
+		dst.SetInt(int64(i))
+		return true, nil
+	}
+
+	orders := []Order{
+		{Items: map[string]Item{"sku": {Name: "foo"}}},
+		{Items: map[string]Item{"sku": {Name: "bar", Price: Money{Amount: 100}}}},
+	}
+
+	tests := []struct {
+		name  string
+		src   interface{}
+		opts  []xgo.Option
+		paths []string
+		want  interface{}
+	}{
+		{
+			name:  "path to the field",
+			src:   Customer{Rank: "1", Orders: orders},
+			paths: []string{`Orders[0].Items["sku"].Price`},
+		},
+		{
+			name:  "custom setter error",
+			src:   Customer{Rank: "invalid"},
+			paths: []string{"Rank"},
+		},
+		{
+			name:  "collect errors",
+			src:   Customer{Rank: "invalid", Orders: orders},
+			opts:  []xgo.Option{xgo.CollectErrors()},
+			paths: []string{"Rank", `Orders[0].Items["sku"].Price`, `Orders[1].Items["sku"].Price`},
+			want: &CustomerDTO{
+				Orders: []OrderDTO{
+					{Items: map[string]ItemDTO{"sku": {Name: "foo"}}},
+					{Items: map[string]ItemDTO{"sku": {Name: "bar"}}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := &CustomerDTO{}
+			err := xgo.DeepCopyWithCustomSetter(tt.src, got, setter, tt.opts...)
+			if err == nil {
+				t.Fatalf("testing %s: should be error for %#v but not", tt.name, tt.src)
+			}
+
+			var errs []error
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				errs = joined.Unwrap()
+			} else {
+				errs = []error{err}
+			}
+			var paths []string
+			for _, err := range errs {
+				var copyErr *xgo.CopyError
+				if !errors.As(err, &copyErr) {
+					t.Fatalf("testing %s: should be CopyError but: %v", tt.name, err)
+				}
+				paths = append(paths, copyErr.Path)
+			}
+			if diff := cmp.Diff(tt.paths, paths); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+
+			if tt.want != nil {
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+				}
+			}
+		})
+	}
+
+	t.Run("unwrap", func(t *testing.T) {
+		err := xgo.DeepCopyWithCustomSetter(Customer{Rank: "invalid"}, &CustomerDTO{}, setter)
+		if !errors.Is(err, errInvalid) {
+			t.Errorf("should be %v but: %v", errInvalid, err)
+		}
+		var copyErr *xgo.CopyError
+		if !errors.As(err, &copyErr) {
+			t.Fatalf("should be CopyError but: %v", err)
+		}
+		if copyErr.SrcType != reflect.TypeOf("") || copyErr.DstType != reflect.TypeOf(0) {
+			t.Errorf("types mismatch: %v, %v", copyErr.SrcType, copyErr.DstType)
+		}
+	})
+}
+
+func TestDeepCopy_options(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type User struct {
+		ID        string
+		Name      string
+		Age       int
+		CreatedAt time.Time
+		Address   *Address
+	}
+	type UserDTO struct {
+		ID        string
+		Name      string
+		Age       int
+		CreatedAt string
+		Address   *Address
+	}
+	type LowerDTO struct {
+		Id   string
+		NAME string
+	}
+	type ExtraDTO struct {
+		ID    string
+		Email string
+	}
+	type Geo struct {
+		Lat float64
+	}
+	type GeoDTO struct {
+		Lat float32
+	}
+	type Place struct {
+		Address struct {
+			Geo Geo
+		}
+	}
+	type PlaceDTO struct {
+		Address struct {
+			Geo GeoDTO
+		}
+	}
+	type Money struct {
+		Amount   int64
+		Currency string
+	}
+	type Item struct {
+		Price Money
+	}
+	type ItemDTO struct {
+		Price string
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	moneyToString := func(m Money) (string, error) {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency), nil
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+		opts []xgo.Option
+	}
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+		err  bool
+	}{
+		{
+			name: "ignore empty",
+			in: args{
+				src:  User{Name: "R2D2"},
+				dest: &UserDTO{ID: "xxxx", Name: "C3PO", Age: 33},
+				opts: []xgo.Option{xgo.IgnoreEmpty()},
+			},
+			want: &UserDTO{ID: "xxxx", Name: "R2D2", Age: 33},
+		},
+		{
+			name: "ignore empty from map",
+			in: args{
+				src:  map[string]interface{}{"ID": "", "Name": "R2D2"},
+				dest: &UserDTO{ID: "xxxx"},
+				opts: []xgo.Option{xgo.IgnoreEmpty()},
+			},
+			want: &UserDTO{ID: "xxxx", Name: "R2D2"},
+		},
+		{
+			name: "strict",
+			in: args{
+				src:  User{ID: "xxxx"},
+				dest: &ExtraDTO{},
+				opts: []xgo.Option{xgo.Strict()},
+			},
+			err: true,
+		},
+		{
+			name: "strict all fields matched",
+			in: args{
+				src:  User{ID: "xxxx", Name: "R2D2"},
+				dest: &UserDTO{},
+				opts: []xgo.Option{xgo.Strict()},
+			},
+			want: &UserDTO{ID: "xxxx", Name: "R2D2", CreatedAt: "0001-01-01T00:00:00Z"},
+		},
+		{
+			name: "strict from map",
+			in: args{
+				src:  map[string]interface{}{"ID": "xxxx"},
+				dest: &ExtraDTO{},
+				opts: []xgo.Option{xgo.Strict()},
+			},
+			err: true,
+		},
+		{
+			name: "case insensitive",
+			in: args{
+				src:  User{ID: "xxxx", Name: "R2D2"},
+				dest: &LowerDTO{},
+				opts: []xgo.Option{xgo.CaseInsensitive()},
+			},
+			want: &LowerDTO{Id: "xxxx", NAME: "R2D2"},
+		},
+		{
+			name: "case sensitive by default",
+			in: args{
+				src:  User{ID: "xxxx", Name: "R2D2"},
+				dest: &LowerDTO{},
+			},
+			want: &LowerDTO{},
+		},
+		{
+			name: "case insensitive from map",
+			in: args{
+				src:  map[string]interface{}{"id": "xxxx", "name": "R2D2"},
+				dest: &LowerDTO{},
+				opts: []xgo.Option{xgo.CaseInsensitive()},
+			},
+			want: &LowerDTO{Id: "xxxx", NAME: "R2D2"},
+		},
+		{
+			name: "time format",
+			in: args{
+				src:  User{CreatedAt: now},
+				dest: &UserDTO{},
+				opts: []xgo.Option{xgo.WithTimeFormat(time.DateOnly)},
+			},
+			want: &UserDTO{CreatedAt: "2025-06-01"},
+		},
+		{
+			name: "time format to time.Time",
+			in: args{
+				src:  UserDTO{CreatedAt: "2025-06-01"},
+				dest: &User{},
+				opts: []xgo.Option{xgo.WithTimeFormat(time.DateOnly)},
+			},
+			want: &User{CreatedAt: now},
+		},
+		{
+			name: "max depth",
+			in: args{
+				src:  Place{},
+				dest: &PlaceDTO{},
+				opts: []xgo.Option{xgo.MaxDepth(1)},
+			},
+			err: true,
+		},
+		{
+			name: "within max depth",
+			in: args{
+				src:  User{Address: &Address{City: "Tokyo"}},
+				dest: &UserDTO{},
+				opts: []xgo.Option{xgo.MaxDepth(1)},
+			},
+			want: &UserDTO{CreatedAt: "0001-01-01T00:00:00Z", Address: &Address{City: "Tokyo"}},
+		},
+		{
+			name: "converter",
+			in: args{
+				src:  Item{Price: Money{Amount: 100, Currency: "JPY"}},
+				dest: &ItemDTO{},
+				opts: []xgo.Option{xgo.WithConverter(moneyToString)},
+			},
+			want: &ItemDTO{Price: "100 JPY"},
+		},
+		{
+			name: "converter for nested values",
+			in: args{
+				src:  map[string][]Item{"foo": {{Price: Money{Amount: 100, Currency: "JPY"}}}},
+				dest: &map[string][]ItemDTO{},
+				opts: []xgo.Option{xgo.WithConverter(moneyToString)},
+			},
+			want: &map[string][]ItemDTO{"foo": {{Price: "100 JPY"}}},
+		},
+		{
+			name: "converter error",
+			in: args{
+				src:  Item{},
+				dest: &ItemDTO{},
+				opts: []xgo.Option{xgo.WithConverter(func(m Money) (string, error) {
+					return "", errors.New("invalid")
+				})},
+			},
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.in.src, tt.in.dest, tt.in.opts...)
+			if tt.err {
+				if err == nil {
+					t.Errorf("testing %s: should be error for %#v but not", tt.name, tt.in.src)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in.src, err)
+			}
+			if diff := cmp.Diff(tt.want, tt.in.dest); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopyWithCustomSetter_nested(t *testing.T) {
+	type Score struct {
+		Value string
+	}
+	type ScoreDTO struct {
+		Value int
+	}
+	type Player struct {
+		Score  Score
+		Best   *Score
+		Scores []*Score
+		Rounds map[string]Score
+	}
+	type PlayerDTO struct {
+		Score  ScoreDTO
+		Best   *ScoreDTO
+		Scores []ScoreDTO
+		Rounds map[string]*ScoreDTO
+	}
+	type Team struct {
+		Players []Player
+	}
+	type TeamDTO struct {
+		Players []*PlayerDTO
+	}
+	type Grid struct {
+		Cells [][]string
+	}
+	type GridDTO struct {
+		Cells [][]int
+	}
+
+	// string -> int
+	setter := func(src, dst reflect.Value) (bool, error) {
+		if src.Kind() != reflect.String || dst.Kind() != reflect.Int {
+			return false, nil
+		}
+		i, err := strconv.Atoi(src.String())
+		if err != nil {
+			return false, err
+		}
+		dst.SetInt(int64(i))
+		return true, nil
+	}
+
+	player := Player{
+		Score:  Score{Value: "1"},
+		Best:   &Score{Value: "2"},
+		Scores: []*Score{{Value: "3"}, {Value: "4"}},
+		Rounds: map[string]Score{"final": {Value: "5"}},
+	}
+	playerDTO := PlayerDTO{
+		Score:  ScoreDTO{Value: 1},
+		Best:   &ScoreDTO{Value: 2},
+		Scores: []ScoreDTO{{Value: 3}, {Value: 4}},
+		Rounds: map[string]*ScoreDTO{"final": {Value: 5}},
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+	}
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+	}{
+		{
+			name: "nested struct, pointer, slice and map",
+			in: args{
+				src:  player,
+				dest: &PlayerDTO{},
+			},
+			want: &playerDTO,
+		},
+		{
+			name: "two levels deep",
+			in: args{
+				src:  Team{Players: []Player{player}},
+				dest: &TeamDTO{},
+			},
+			want: &TeamDTO{Players: []*PlayerDTO{&playerDTO}},
+		},
+		{
+			name: "top-level slice",
+			in: args{
+				src:  []*Player{&player, &player},
+				dest: &[]PlayerDTO{},
+			},
+			want: &[]PlayerDTO{playerDTO, playerDTO},
+		},
+		{
+			name: "top-level slice of scalars",
+			in: args{
+				src:  []string{"1", "2"},
+				dest: &[]int{},
+			},
+			want: &[]int{1, 2},
+		},
+		{
+			name: "slice of slices",
+			in: args{
+				src:  Grid{Cells: [][]string{{"1", "2"}, {"3"}, nil}},
+				dest: &GridDTO{},
+			},
+			want: &GridDTO{Cells: [][]int{{1, 2}, {3}, nil}},
+		},
+		{
+			name: "top-level slice of slices",
+			in: args{
+				src:  [][]string{{"1"}, {"2", "3"}},
+				dest: &[][]int{},
+			},
+			want: &[][]int{{1}, {2, 3}},
+		},
+		{
+			name: "map elements",
+			in: args{
+				src:  map[string][]string{"a": {"1", "2"}},
+				dest: &map[string][]int{},
+			},
+			want: &map[string][]int{"a": {1, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopyWithCustomSetter(tt.in.src, tt.in.dest, setter)
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in.src, err)
+			}
+			if diff := cmp.Diff(tt.want, tt.in.dest); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopy_cycle(t *testing.T) {
+	type Node struct {
+		Name     string
+		Parent   *Node
+		Children []*Node
+	}
+	type NodeDTO struct {
+		Name     string
+		Parent   *NodeDTO
+		Children []*NodeDTO
+	}
+	type Item struct {
+		Name string
+	}
+	type ItemDTO struct {
+		Name string
+	}
+	type Pair struct {
+		First  *Item
+		Second *Item
+		Items  map[string]*Item
+	}
+	type PairDTO struct {
+		First  *ItemDTO
+		Second *ItemDTO
+		Items  map[string]*ItemDTO
+	}
+
+	root := &Node{Name: "root"}
+	child := &Node{Name: "child", Parent: root}
+	root.Children = []*Node{child, child}
+	// self-referencing
+	self := &Node{Name: "self"}
+	self.Parent = self
+
+	t.Run("parent pointers", func(t *testing.T) {
+		got := &NodeDTO{}
+		if err := xgo.DeepCopy(root, got); err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		if got.Name != "root" || len(got.Children) != 2 || got.Children[0].Name != "child" {
+			t.Fatalf("unexpected copy: %+v", got)
+		}
+		if got.Children[0].Parent != got {
+			t.Errorf("parent should be the copied root but: %p, %p", got.Children[0].Parent, got)
+		}
+		if got.Children[0] != got.Children[1] {
+			t.Errorf("children should be the same pointer but: %p, %p", got.Children[0], got.Children[1])
+		}
+	})
+
+	t.Run("self reference in a struct field", func(t *testing.T) {
+		got := struct{ Node *NodeDTO }{}
+		if err := xgo.DeepCopy(struct{ Node *Node }{Node: self}, &got); err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		if got.Node == nil || got.Node.Parent != got.Node {
+			t.Errorf("parent should be the node itself but: %+v", got.Node)
+		}
+	})
+
+	t.Run("shared pointers", func(t *testing.T) {
+		item := &Item{Name: "foo"}
+		got := &PairDTO{}
+		err := xgo.DeepCopy(Pair{First: item, Second: item, Items: map[string]*Item{"foo": item}}, got)
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(&ItemDTO{Name: "foo"}, got.First); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+		if got.First != got.Second || got.First != got.Items["foo"] {
+			t.Errorf("should be the same pointer but: %p, %p, %p", got.First, got.Second, got.Items["foo"])
+		}
+	})
+
+	t.Run("different pointers", func(t *testing.T) {
+		got := &PairDTO{}
+		if err := xgo.DeepCopy(Pair{First: &Item{Name: "foo"}, Second: &Item{Name: "foo"}}, got); err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		if got.First == got.Second {
+			t.Errorf("should be different pointers but: %p", got.First)
+		}
+	})
+
+	t.Run("error on cycle", func(t *testing.T) {
+		var copyErr *xgo.CopyError
+		err := xgo.DeepCopy(root, &NodeDTO{}, xgo.ErrorOnCycle())
+		if !errors.As(err, &copyErr) {
+			t.Fatalf("should be CopyError but: %v", err)
+		}
+		if copyErr.Path != "Children[0].Parent" {
+			t.Errorf("path mismatch: %s", copyErr.Path)
+		}
+
+		// shared pointers are not a cycle
+		item := &Item{Name: "foo"}
+		if err := xgo.DeepCopy(Pair{First: item, Second: item}, &PairDTO{}, xgo.ErrorOnCycle()); err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+	})
+}
+
+type paymentMethod interface {
+	Amount() int64
+}
+
+type card struct {
+	Number string
+	Total  int64
+}
+
+func (c *card) Amount() int64 { return c.Total }
+
+// paymentMethodDTO is an interface of another package that *card does not implement
+type paymentMethodDTO interface {
+	GetAmount() int64
+}
+
+type cardDTO struct {
+	Number string
+	Total  int64
+}
+
+func (c *cardDTO) GetAmount() int64 { return c.Total }
+
+func TestDeepCopy_interface(t *testing.T) {
+	type Payment struct {
+		Method  paymentMethod
+		Meta    interface{}
+		Options any
+	}
+	type PaymentDTO struct {
+		Method  paymentMethodDTO
+		Meta    interface{}
+		Options *card
+	}
+	type Order struct {
+		Method paymentMethod
+	}
+	type OrderDTO struct {
+		Method paymentMethod
+	}
+
+	toCardDTO := xgo.WithConcreteType(func(src, dst reflect.Type) reflect.Type {
+		if dst == reflect.TypeOf((*paymentMethodDTO)(nil)).Elem() {
+			return reflect.TypeOf(&cardDTO{})
+		}
+		return nil
+	})
+
+	t.Run("concrete value is copied", func(t *testing.T) {
+		src := Order{Method: &card{Number: "1234", Total: 100}}
+		got := &OrderDTO{}
+		if err := xgo.DeepCopy(src, got); err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(&OrderDTO{Method: &card{Number: "1234", Total: 100}}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+		if got.Method == src.Method {
+			t.Errorf("should not share the pointer: %p", got.Method)
+		}
+	})
+
+	t.Run("nested values in interface{}", func(t *testing.T) {
+		meta := map[string]interface{}{
+			"tags": []interface{}{"foo", map[string]interface{}{"bar": 1}},
+		}
+		src := Payment{Meta: meta, Options: card{Number: "1234"}}
+		got := &PaymentDTO{}
+		if err := xgo.DeepCopy(src, got, toCardDTO); err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		want := &PaymentDTO{Meta: meta, Options: &card{Number: "1234"}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+		// modifying the copy does not change the source
+		got.Meta.(map[string]interface{})["tags"].([]interface{})[1].(map[string]interface{})["bar"] = 2
+		if meta["tags"].([]interface{})[1].(map[string]interface{})["bar"] != 1 {
+			t.Errorf("should not share the map: %v", meta)
+		}
+	})
+
+	t.Run("concrete type of another package", func(t *testing.T) {
+		got := &PaymentDTO{}
+		err := xgo.DeepCopy(Payment{Method: &card{Number: "1234", Total: 100}}, got, toCardDTO)
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(&PaymentDTO{Method: &cardDTO{Number: "1234", Total: 100}}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("nil interface", func(t *testing.T) {
+		got := &PaymentDTO{}
+		if err := xgo.DeepCopy(Payment{}, got); err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(&PaymentDTO{}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("no concrete type", func(t *testing.T) {
+		err := xgo.DeepCopy(Payment{Method: &card{}}, &PaymentDTO{})
+		var copyErr *xgo.CopyError
+		if !errors.As(err, &copyErr) {
+			t.Fatalf("should be CopyError but: %v", err)
+		}
+		if copyErr.Path != "Method" {
+			t.Errorf("path mismatch: %s", copyErr.Path)
+		}
+	})
+
+	t.Run("unexported fields", func(t *testing.T) {
+		type Mixed struct {
+			Pub  int
+			priv int
+		}
+		type Holder struct {
+			V interface{}
+		}
+		got := &Holder{}
+		if err := xgo.DeepCopy(Holder{V: Mixed{Pub: 1, priv: 2}}, got); err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(&Holder{V: Mixed{Pub: 1, priv: 2}}, got, cmp.AllowUnexported(Mixed{})); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+}
+
+func TestDeepCopy_array(t *testing.T) {
+	type UUID [16]byte
+	type Point struct {
+		X, Y int32
+	}
+	type PointDTO struct {
+		X, Y int64
+	}
+	type Shape struct {
+		ID     UUID
+		Points [3]Point
+	}
+	type ShapeDTO struct {
+		ID     [16]byte
+		Points [3]PointDTO
+	}
+	type ShapeSlice struct {
+		ID     []byte
+		Points []*PointDTO
+	}
+	type ShapeArray struct {
+		Points [2]PointDTO
+	}
+
+	id := UUID{0x01, 0x02, 0x03}
+	points := [3]Point{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 5, Y: 6}}
+	pointDTOs := [3]PointDTO{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 5, Y: 6}}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+		opts []xgo.Option
+	}
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+		err  bool
+	}{
+		{
+			name: "array to array",
+			in: args{
+				src:  Shape{ID: id, Points: points},
+				dest: &ShapeDTO{},
+			},
+			want: &ShapeDTO{ID: [16]byte(id), Points: pointDTOs},
+		},
+		{
+			name: "array to slice",
+			in: args{
+				src:  Shape{ID: id, Points: points},
+				dest: &ShapeSlice{},
+			},
+			want: &ShapeSlice{
+				ID:     id[:],
+				Points: []*PointDTO{&pointDTOs[0], &pointDTOs[1], &pointDTOs[2]},
+			},
+		},
+		{
+			name: "slice to array",
+			in: args{
+				src:  ShapeSlice{ID: id[:], Points: []*PointDTO{{X: 1}, {X: 2}, {X: 3}}},
+				dest: &Shape{},
+			},
+			want: &Shape{ID: id, Points: [3]Point{{X: 1}, {X: 2}, {X: 3}}},
+		},
+		{
+			name: "shorter slice to array",
+			in: args{
+				src:  ShapeSlice{ID: []byte{0x01}, Points: []*PointDTO{{X: 1}}},
+				dest: &Shape{ID: UUID{0xff, 0xff}, Points: points},
+			},
+			want: &Shape{ID: UUID{0x01}, Points: [3]Point{{X: 1}}},
+		},
+		{
+			name: "array truncated",
+			in: args{
+				src:  Shape{Points: points},
+				dest: &ShapeArray{},
+			},
+			want: &ShapeArray{Points: [2]PointDTO{{X: 1, Y: 2}, {X: 3, Y: 4}}},
+		},
+		{
+			name: "nil slice to array",
+			in: args{
+				src:  ShapeSlice{},
+				dest: &Shape{ID: id},
+			},
+			want: &Shape{ID: id},
+		},
+		{
+			name: "strict length",
+			in: args{
+				src:  Shape{Points: points},
+				dest: &ShapeArray{},
+				opts: []xgo.Option{xgo.StrictLength()},
+			},
+			err: true,
+		},
+		{
+			name: "strict length shorter slice",
+			in: args{
+				src:  ShapeSlice{ID: []byte{0x01}},
+				dest: &Shape{},
+				opts: []xgo.Option{xgo.StrictLength()},
+			},
+			err: true,
+		},
+		{
+			name: "top-level array",
+			in: args{
+				src:  points,
+				dest: &[]PointDTO{},
+			},
+			want: &[]PointDTO{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 5, Y: 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.in.src, tt.in.dest, tt.in.opts...)
+			if tt.err {
+				if err == nil {
+					t.Errorf("testing %s: should be error for %#v but not", tt.name, tt.in.src)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in.src, err)
+			}
+			if diff := cmp.Diff(tt.want, tt.in.dest); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopy_tag(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type User struct {
+		ID       string `copier:"name=Id;to=UserPB.UserId"`
+		Name     string `copier:",omitempty"`
+		Password string `copier:"-"`
+		Email    string `copier:",required"`
+		Address  *Address
+		Tags     []string `copier:",deep"`
+		Labels   []string `copier:",shallow"`
+	}
+	type UserDTO struct {
+		Id       string
+		Name     string
+		Password string
+		Email    string
+		Address  *Address `copier:",deep"`
+		Tags     []string
+		Labels   []string
+	}
+	type UserPB struct {
+		UserId  string
+		Name    string
+		Email   string
+		Address *Address
+		Secret  string `copier:"-"`
+	}
+	type Account struct {
+		Email string
+	}
+	type AccountDTO struct {
+		Email string
+		Token string `copier:",required"`
+	}
+
+	addr := &Address{City: "Tokyo"}
+	tags := []string{"a", "b"}
+	labels := []string{"c"}
+	user := User{
+		ID:       "xxxx",
+		Name:     "R2D2",
+		Password: "secret",
+		Email:    "r2d2@example.com",
+		Address:  addr,
+		Tags:     tags,
+		Labels:   labels,
+	}
+
+	t.Run("options", func(t *testing.T) {
+		t.Parallel()
+		got := &UserDTO{Name: "C3PO"}
+		err := xgo.DeepCopy(User{ID: "xxxx", Email: "r2d2@example.com"}, got)
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		want := &UserDTO{Id: "xxxx", Name: "C3PO", Email: "r2d2@example.com"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("deep and shallow", func(t *testing.T) {
+		t.Parallel()
+		got := &UserDTO{}
+		err := xgo.DeepCopy(user, got)
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		want := &UserDTO{
+			Id:      "xxxx",
+			Name:    "R2D2",
+			Email:   "r2d2@example.com",
+			Address: &Address{City: "Tokyo"},
+			Tags:    []string{"a", "b"},
+			Labels:  []string{"c"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+		if got.Address == addr || &got.Tags[0] == &tags[0] {
+			t.Errorf("the deep fields should be copied")
+		}
+		if &got.Labels[0] != &labels[0] {
+			t.Errorf("the shallow field should be assigned")
+		}
+	})
+
+	t.Run("target type", func(t *testing.T) {
+		t.Parallel()
+		got := &UserPB{Secret: "foo"}
+		err := xgo.DeepCopy(user, got)
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		want := &UserPB{
+			UserId:  "xxxx",
+			Name:    "R2D2",
+			Email:   "r2d2@example.com",
+			Address: addr,
+			Secret:  "foo",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("required source field", func(t *testing.T) {
+		t.Parallel()
+		err := xgo.DeepCopy(User{ID: "xxxx"}, &UserDTO{})
+		var copyErr *xgo.CopyError
+		if !errors.As(err, &copyErr) {
+			t.Fatalf("should be CopyError but: %v", err)
+		}
+		if copyErr.Path != "Email" {
+			t.Errorf("the path should be Email but: %s", copyErr.Path)
+		}
+	})
+
+	t.Run("required destination field", func(t *testing.T) {
+		t.Parallel()
+		err := xgo.DeepCopy(Account{Email: "r2d2@example.com"}, &AccountDTO{})
+		var copyErr *xgo.CopyError
+		if !errors.As(err, &copyErr) {
+			t.Fatalf("should be CopyError but: %v", err)
+		}
+		if copyErr.Path != "Token" {
+			t.Errorf("the path should be Token but: %s", copyErr.Path)
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		t.Parallel()
+		got := map[string]interface{}{}
+		err := xgo.DeepCopy(User{ID: "xxxx", Email: "r2d2@example.com"}, &got)
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		want := map[string]interface{}{
+			"Id":      "xxxx",
+			"Email":   "r2d2@example.com",
+			"Address": nil,
+			"Tags":    nil,
+			"Labels":  nil,
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+}
+
+func TestDeepCopy_tagPath(t *testing.T) {
+	type Geo struct {
+		Lat float64
+	}
+	type Address struct {
+		City string
+		Geo  *Geo
+	}
+	type User struct {
+		Name    string
+		Address *Address
+	}
+	type UserDTO struct {
+		Name        string
+		AddressCity string  `copier:"Address.City"`
+		Lat         float64 `copier:"Address.Geo.Lat"`
+	}
+
+	tests := []struct {
+		name string
+		src  interface{}
+		dst  interface{}
+		want interface{}
+	}{
+		{
+			name: "flatten",
+			src:  User{Name: "R2D2", Address: &Address{City: "Tokyo", Geo: &Geo{Lat: 35.6}}},
+			dst:  &UserDTO{},
+			want: &UserDTO{Name: "R2D2", AddressCity: "Tokyo", Lat: 35.6},
+		},
+		{
+			name: "flatten nil pointer",
+			src:  User{Name: "R2D2", Address: &Address{City: "Tokyo"}},
+			dst:  &UserDTO{},
+			want: &UserDTO{Name: "R2D2", AddressCity: "Tokyo"},
+		},
+		{
+			name: "unflatten",
+			src:  UserDTO{Name: "R2D2", AddressCity: "Tokyo", Lat: 35.6},
+			dst:  &User{},
+			want: &User{Name: "R2D2", Address: &Address{City: "Tokyo", Geo: &Geo{Lat: 35.6}}},
+		},
+		{
+			name: "unflatten zero value",
+			src:  UserDTO{Name: "R2D2", AddressCity: "Tokyo"},
+			dst:  &User{},
+			want: &User{Name: "R2D2", Address: &Address{City: "Tokyo"}},
+		},
+		{
+			name: "unflatten nothing",
+			src:  UserDTO{Name: "R2D2"},
+			dst:  &User{},
+			want: &User{Name: "R2D2"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.src, tt.dst)
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.src, err)
+			}
+			if diff := cmp.Diff(tt.want, tt.dst); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		t.Parallel()
+		err := xgo.DeepCopy(User{Address: &Address{}}, &UserDTO{}, xgo.Strict())
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+	})
+
+	t.Run("explain", func(t *testing.T) {
+		t.Parallel()
+		got, err := xgo.Explain(reflect.TypeOf(UserDTO{}), reflect.TypeOf(User{}))
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		want := &xgo.Report{
+			Fields: []xgo.FieldMapping{
+				{Src: "Name", Dst: "Name", Converter: "assign"},
+				{Src: "AddressCity", Dst: "Address.City", ByTag: true, Converter: "assign"},
+				{Src: "Lat", Dst: "Address.Geo.Lat", ByTag: true, Converter: "assign"},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+}
+
+func TestDeepCopy_timeLayouts(t *testing.T) {
+	type Event struct {
+		StartAt time.Time
+		EndAt   *time.Time
+	}
+	type EventDTO struct {
+		StartAt string
+		EndAt   *string
+	}
+
+	date := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	layouts := xgo.WithTimeLayouts(time.RFC3339, time.DateOnly, xgo.TimeUnix)
+
+	type args struct {
+		src  EventDTO
+		opts []xgo.Option
+	}
+	tests := []struct {
+		name string
+		in   args
+		want Event
+		err  bool
+	}{
+		{
+			name: "default layout",
+			in:   args{src: EventDTO{StartAt: "2025-06-01T00:00:00Z"}},
+			want: Event{StartAt: date},
+		},
+		{
+			name: "invalid time",
+			in:   args{src: EventDTO{StartAt: "2025-06-01"}},
+			err:  true,
+		},
+		{
+			name: "invalid time pointer",
+			in:   args{src: EventDTO{EndAt: xgo.ToPtr("2025-06-01")}},
+			err:  true,
+		},
+		{
+			name: "empty string",
+			in:   args{src: EventDTO{EndAt: xgo.ToPtr("")}},
+			want: Event{},
+		},
+		{
+			name: "lenient",
+			in:   args{src: EventDTO{StartAt: "2025-06-01"}, opts: []xgo.Option{xgo.LenientTime()}},
+			want: Event{},
+		},
+		{
+			name: "layouts in order",
+			in: args{
+				src:  EventDTO{StartAt: "2025-06-01", EndAt: xgo.ToPtr("2025-06-01T00:00:00Z")},
+				opts: []xgo.Option{layouts},
+			},
+			want: Event{StartAt: date, EndAt: &date},
+		},
+		{
+			name: "unix seconds",
+			in:   args{src: EventDTO{StartAt: "1748736000"}, opts: []xgo.Option{layouts}},
+			want: Event{StartAt: time.Unix(1748736000, 0)},
+		},
+		{
+			name: "no layout matches",
+			in:   args{src: EventDTO{StartAt: "06/01/2025"}, opts: []xgo.Option{layouts}},
+			err:  true,
+		},
+	}
+
+	t.Run("lenient keeps the destination", func(t *testing.T) {
+		t.Parallel()
+		got := Event{StartAt: date}
+		if err := xgo.DeepCopy(EventDTO{StartAt: "junk"}, &got, xgo.LenientTime()); err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(Event{StartAt: date}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Event{}
+			err := xgo.DeepCopy(tt.in.src, &got, tt.in.opts...)
+			if tt.err {
+				var copyErr *xgo.CopyError
+				if !errors.As(err, &copyErr) {
+					t.Errorf("testing %s: should be CopyError but: %v", tt.name, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopy_epochUnits(t *testing.T) {
+	type Event struct {
+		StartAt time.Time
+		EndAt   *time.Time
+		DueAt   time.Time `copier:",unixmilli"`
+	}
+	type EventDTO struct {
+		StartAt int64
+		EndAt   *float64
+		DueAt   uint64
+	}
+	type SmallDTO struct {
+		StartAt int32
+	}
+
+	date := time.Date(2025, 6, 1, 0, 0, 0, 500000000, time.UTC)
+
+	type args struct {
+		src  interface{}
+		dst  interface{}
+		opts []xgo.Option
+	}
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+		err  bool
+	}{
+		{
+			name: "time to nanoseconds by default",
+			in:   args{src: Event{StartAt: date, EndAt: &date, DueAt: date}, dst: &EventDTO{}},
+			want: &EventDTO{StartAt: 1748736000500000000, EndAt: xgo.ToPtr(1748736000500000000.0), DueAt: 1748736000500},
+		},
+		{
+			name: "time to seconds",
+			in: args{
+				src:  Event{StartAt: date, EndAt: &date, DueAt: date},
+				dst:  &EventDTO{},
+				opts: []xgo.Option{xgo.WithEpochUnit(xgo.UnixSeconds)},
+			},
+			want: &EventDTO{StartAt: 1748736000, EndAt: xgo.ToPtr(1748736000.5), DueAt: 1748736000500},
+		},
+		{
+			name: "milliseconds to time",
+			in: args{
+				src:  EventDTO{StartAt: 1748736000500, EndAt: xgo.ToPtr(1748736000500.0), DueAt: 1748736000500},
+				dst:  &Event{},
+				opts: []xgo.Option{xgo.WithEpochUnit(xgo.UnixMilli)},
+			},
+			want: &Event{StartAt: time.UnixMilli(1748736000500), EndAt: xgo.ToPtr(time.UnixMilli(1748736000500)), DueAt: time.UnixMilli(1748736000500)},
+		},
+		{
+			name: "microseconds to time",
+			in: args{
+				src:  EventDTO{StartAt: 1748736000500000},
+				dst:  &Event{},
+				opts: []xgo.Option{xgo.WithEpochUnit(xgo.UnixMicro)},
+			},
+			want: &Event{StartAt: time.UnixMicro(1748736000500000), DueAt: time.UnixMilli(0)},
+		},
+		{
+			name: "seconds fit int32",
+			in:   args{src: Event{StartAt: date}, dst: &SmallDTO{}, opts: []xgo.Option{xgo.WithEpochUnit(xgo.UnixSeconds)}},
+			want: &SmallDTO{StartAt: int32(1748736000)},
+		},
+		{
+			name: "nanoseconds do not fit int32",
+			in:   args{src: Event{StartAt: date}, dst: &SmallDTO{}},
+			err:  true,
+		},
+		{
+			name: "time before the epoch to uint64",
+			in:   args{src: Event{DueAt: time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)}, dst: &EventDTO{}},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.in.src, tt.in.dst, tt.in.opts...)
+			if tt.err {
+				var copyErr *xgo.CopyError
+				if !errors.As(err, &copyErr) {
+					t.Errorf("testing %s: should be CopyError but: %v", tt.name, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, tt.in.dst); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopy_timeLocation(t *testing.T) {
+	type Event struct {
+		At time.Time
+	}
+	type EventPtr struct {
+		At *time.Time
+	}
+	type EventDTO struct {
+		At int64
+	}
+	type EventString struct {
+		At string
+	}
+
+	jst := time.FixedZone("JST", 9*60*60)
+	date := time.Date(2025, 6, 1, 9, 0, 0, 0, jst)
+	now := time.Now()
+
+	type args struct {
+		src  interface{}
+		opts []xgo.Option
+	}
+	tests := []struct {
+		name string
+		in   args
+		want string
+	}{
+		{
+			name: "as it is",
+			in:   args{src: Event{At: date}},
+			want: "2025-06-01 09:00:00 +0900 JST",
+		},
+		{
+			name: "utc",
+			in:   args{src: Event{At: date}, opts: []xgo.Option{xgo.InUTC()}},
+			want: "2025-06-01 00:00:00 +0000 UTC",
+		},
+		{
+			name: "pointer to location",
+			in:   args{src: EventPtr{At: &date}, opts: []xgo.Option{xgo.InLocation(time.UTC)}},
+			want: "2025-06-01 00:00:00 +0000 UTC",
+		},
+		{
+			name: "epoch in location",
+			in:   args{src: EventDTO{At: date.UnixNano()}, opts: []xgo.Option{xgo.InLocation(jst)}},
+			want: "2025-06-01 09:00:00 +0900 JST",
+		},
+		{
+			name: "string in location",
+			in: args{
+				src:  EventString{At: "1748736000"},
+				opts: []xgo.Option{xgo.WithTimeLayouts(xgo.TimeUnix), xgo.InUTC()},
+			},
+			want: "2025-06-01 00:00:00 +0000 UTC",
+		},
+		{
+			name: "zero time",
+			in:   args{src: Event{}, opts: []xgo.Option{xgo.InLocation(jst)}},
+			want: "0001-01-01 00:00:00 +0000 UTC",
+		},
+		{
+			name: "strip monotonic",
+			in:   args{src: Event{At: now}, opts: []xgo.Option{xgo.StripMonotonic()}},
+			want: now.Round(0).String(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Event{}
+			if err := xgo.DeepCopy(tt.in.src, &got, tt.in.opts...); err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, got.At.String()); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+
+	t.Run("format in location", func(t *testing.T) {
+		t.Parallel()
+		got := EventString{}
+		if err := xgo.DeepCopy(Event{At: date}, &got, xgo.InUTC()); err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(EventString{At: "2025-06-01T00:00:00Z"}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("struct to map", func(t *testing.T) {
+		t.Parallel()
+		type Schedule struct {
+			At    time.Time
+			Dates []time.Time
+		}
+		got := map[string]interface{}{}
+		src := Schedule{At: date, Dates: []time.Time{date}}
+		if err := xgo.DeepCopy(src, &got, xgo.InUTC()); err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if at, ok := got["At"].(time.Time); !ok || at.Location() != time.UTC {
+			t.Errorf("should be in UTC but: %v", got["At"])
+		}
+		if dates, ok := got["Dates"].([]time.Time); !ok || len(dates) != 1 || dates[0].Location() != time.UTC {
+			t.Errorf("should be in UTC but: %v", got["Dates"])
+		}
+		if src.Dates[0].Location() != jst {
+			t.Errorf("should not change the source: %v", src.Dates[0])
+		}
+	})
+}
+
 type Field1[T any] struct {
 	ValToVal T
 	ValToPtr T
@@ -918,3 +2800,131 @@ func TestDeepCopy_customtype(t *testing.T) {
 	test(t, test8)
 	test(t, test9)
 }
+
+func TestDeepCopy_concurrent(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price int64
+	}
+	type Order struct {
+		ID    string `copier:"OrderID"`
+		Items []*Item
+	}
+	type OrderDTO struct {
+		OrderID string
+		Items   []Item
+	}
+
+	src := Order{
+		ID:    "id",
+		Items: []*Item{{Name: "foo", Price: 100}, {Name: "bar", Price: 200}},
+	}
+	want := &OrderDTO{
+		OrderID: "id",
+		Items:   []Item{{Name: "foo", Price: 100}, {Name: "bar", Price: 200}},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got := &OrderDTO{}
+			if err := xgo.DeepCopy(src, got); err != nil {
+				t.Errorf("should not be error but: %v", err)
+				return
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s\n", diff)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type benchModelA struct {
+	ID        string `copier:"Id"`
+	Name      string
+	Age       int32
+	Score     float32
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	Tags      []string
+	Address   benchAddress
+	Items     []*benchAddress
+}
+
+type benchModelB struct {
+	Id        string
+	Name      string
+	Age       int64
+	Score     float64
+	CreatedAt int64
+	UpdatedAt *time.Time
+	Tags      []string
+	Address   *benchAddress
+	Items     []benchAddress
+}
+
+type benchAddress struct {
+	Country string
+	City    string
+	Zip     string
+}
+
+func newBenchModel() benchModelA {
+	now := time.Now()
+	return benchModelA{
+		ID:        "xxxx",
+		Name:      "R2D2",
+		Age:       33,
+		Score:     10.625,
+		CreatedAt: now,
+		UpdatedAt: &now,
+		Tags:      []string{"foo", "bar"},
+		Address:   benchAddress{Country: "JP", City: "Tokyo", Zip: "100-0001"},
+		Items: []*benchAddress{
+			{Country: "JP", City: "Osaka", Zip: "530-0001"},
+			{Country: "JP", City: "Kyoto", Zip: "600-8001"},
+		},
+	}
+}
+
+func BenchmarkDeepCopy(b *testing.B) {
+	src := newBenchModel()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := xgo.DeepCopy(src, &benchModelB{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDeepCopy_uncached compiles the copy plans on every call
+func BenchmarkDeepCopy_uncached(b *testing.B) {
+	src := newBenchModel()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		xgo.ResetPlanCache()
+		if err := xgo.DeepCopy(src, &benchModelB{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDeepCopy_slice(b *testing.B) {
+	src := make([]benchModelA, 100)
+	for i := range src {
+		src[i] = newBenchModel()
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst := []benchModelB{}
+		if err := xgo.DeepCopy(src, &dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package xgo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Change is a value that differs between two values.
+// Path is like Orders[3].Items["sku"].Price, the copier tag renames the field.
+// Old or New is nil when the element does not exist on the side.
+type Change struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// Diff returns the changes from a to b, a and b have the same type.
+// It compares the exported fields of the nested structs, pointers, slices and maps,
+// the structs that have no exported fields like time.Time are compared as a whole.
+func Diff(a, b interface{}) ([]Change, error) {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !av.IsValid() || !bv.IsValid() {
+		return nil, fmt.Errorf("cannot diff %T and %T", a, b)
+	}
+	if av.Type() != bv.Type() {
+		return nil, fmt.Errorf("cannot diff %v and %v", av.Type(), bv.Type())
+	}
+
+	d := &differ{}
+	d.diff(av, bv)
+	return d.changes, nil
+}
+
+// differ walks two values of the same type and records the changes
+type differ struct {
+	path    []pathStep
+	changes []Change
+	// visited is the pointer pairs that are compared, for the cycles
+	visited map[[2]uintptr]bool
+}
+
+func (d *differ) add(a, b reflect.Value) {
+	d.changes = append(d.changes, Change{
+		Path: formatPath(d.path),
+		Old:  interfaceOf(a),
+		New:  interfaceOf(b),
+	})
+}
+
+// interfaceOf returns the value that the pointers refer to, it is nil for a nil pointer and a missing element
+func interfaceOf(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func (d *differ) diff(a, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(a, b)
+			}
+			return
+		}
+		key := [2]uintptr{a.Pointer(), b.Pointer()}
+		if a.Pointer() == b.Pointer() || d.visited[key] {
+			return
+		}
+		if d.visited == nil {
+			d.visited = map[[2]uintptr]bool{}
+		}
+		d.visited[key] = true
+		d.diff(a.Elem(), b.Elem())
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() || a.Elem().Type() != b.Elem().Type() {
+			if !a.IsNil() || !b.IsNil() {
+				d.add(a, b)
+			}
+			return
+		}
+		d.diff(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if !mergeable(a.Type()) {
+			if !equal(a, b) {
+				d.add(a, b)
+			}
+			return
+		}
+		for _, f := range getKeyFields(a.Type()) {
+			// the field promoted through a nil embedded pointer is the zero value
+			fa, err := a.FieldByIndexErr(f.index)
+			if err != nil {
+				fa = reflect.Zero(a.Type().FieldByIndex(f.index).Type)
+			}
+			fb, err := b.FieldByIndexErr(f.index)
+			if err != nil {
+				fb = reflect.Zero(b.Type().FieldByIndex(f.index).Type)
+			}
+			d.path = append(d.path, fieldStep(f.key))
+			d.diff(fa, fb)
+			d.path = d.path[:len(d.path)-1]
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < max(a.Len(), b.Len()); i++ {
+			d.path = append(d.path, indexStep(i))
+			switch {
+			case i >= a.Len():
+				d.add(reflect.Value{}, b.Index(i))
+			case i >= b.Len():
+				d.add(a.Index(i), reflect.Value{})
+			default:
+				d.diff(a.Index(i), b.Index(i))
+			}
+			d.path = d.path[:len(d.path)-1]
+		}
+	case reflect.Map:
+		for _, k := range mapKeys(a, b) {
+			d.path = append(d.path, keyStep(k))
+			va, vb := a.MapIndex(k), b.MapIndex(k)
+			if va.IsValid() && vb.IsValid() {
+				d.diff(va, vb)
+			} else {
+				d.add(va, vb)
+			}
+			d.path = d.path[:len(d.path)-1]
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		// not comparable by the value
+	default:
+		if !equal(a, b) {
+			d.add(a, b)
+		}
+	}
+}
+
+// equal compares the values, time.Time is compared by the instant
+func equal(a, b reflect.Value) bool {
+	if !a.CanInterface() {
+		return a.Equal(b)
+	}
+	if t, ok := a.Interface().(time.Time); ok {
+		return t.Equal(b.Interface().(time.Time))
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// mapKeys returns the keys of both maps in order
+func mapKeys(a, b reflect.Value) []reflect.Value {
+	keys := a.MapKeys()
+	for _, k := range b.MapKeys() {
+		if !a.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return lessKey(keys[i], keys[j])
+	})
+	return keys
+}
+
+func lessKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
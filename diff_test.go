@@ -0,0 +1,191 @@
+package xgo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo"
+)
+
+func TestDiff(t *testing.T) {
+	type Address struct {
+		City    string
+		ZipCode string `copier:"zip_code"`
+	}
+	type Base struct {
+		ID string `copier:"Id"`
+	}
+	type Item struct {
+		Name  string
+		Price int
+	}
+	type User struct {
+		Base
+		Name      string
+		Nickname  *string
+		Address   *Address
+		Items     []Item
+		Tags      map[string]string
+		Meta      interface{}
+		UpdatedAt time.Time
+		secret    string
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	user := func() User {
+		return User{
+			Base:      Base{ID: "xxxx"},
+			Name:      "R2D2",
+			Address:   &Address{City: "Tokyo", ZipCode: "100-0001"},
+			Items:     []Item{{Name: "foo", Price: 100}},
+			Tags:      map[string]string{"env": "prod"},
+			Meta:      1,
+			UpdatedAt: now,
+			secret:    "foo",
+		}
+	}
+
+	type args struct {
+		a interface{}
+		b interface{}
+	}
+	tests := []struct {
+		name string
+		in   args
+		want []xgo.Change
+		err  bool
+	}{
+		{
+			name: "no changes",
+			in: args{
+				a: user(),
+				b: func() User {
+					u := user()
+					u.Address = &Address{City: "Tokyo", ZipCode: "100-0001"}
+					u.UpdatedAt = now.In(time.FixedZone("JST", 9*60*60))
+					u.secret = "bar"
+					return u
+				}(),
+			},
+		},
+		{
+			name: "fields",
+			in: args{
+				a: user(),
+				b: func() User {
+					u := user()
+					u.ID = "yyyy"
+					u.Name = "C3PO"
+					u.Nickname = xgo.ToPtr("3PO")
+					u.Address.ZipCode = "150-0001"
+					u.Meta = "1"
+					u.UpdatedAt = now.Add(time.Hour)
+					return u
+				}(),
+			},
+			want: []xgo.Change{
+				{Path: "Id", Old: "xxxx", New: "yyyy"},
+				{Path: "Name", Old: "R2D2", New: "C3PO"},
+				{Path: "Nickname", Old: nil, New: "3PO"},
+				{Path: "Address.zip_code", Old: "100-0001", New: "150-0001"},
+				{Path: "Meta", Old: 1, New: "1"},
+				{Path: "UpdatedAt", Old: now, New: now.Add(time.Hour)},
+			},
+		},
+		{
+			name: "slices and maps",
+			in: args{
+				a: user(),
+				b: func() User {
+					u := user()
+					u.Items = []Item{{Name: "foo", Price: 200}, {Name: "bar"}}
+					u.Tags = map[string]string{"env": "dev", "team": "droid"}
+					return u
+				}(),
+			},
+			want: []xgo.Change{
+				{Path: "Items[0].Price", Old: 100, New: 200},
+				{Path: "Items[1]", Old: nil, New: Item{Name: "bar"}},
+				{Path: `Tags["env"]`, Old: "prod", New: "dev"},
+				{Path: `Tags["team"]`, Old: nil, New: "droid"},
+			},
+		},
+		{
+			name: "removed",
+			in: args{
+				a: user(),
+				b: func() User {
+					u := user()
+					u.Address = nil
+					u.Items = nil
+					u.Tags = nil
+					return u
+				}(),
+			},
+			want: []xgo.Change{
+				{Path: "Address", Old: Address{City: "Tokyo", ZipCode: "100-0001"}, New: nil},
+				{Path: "Items[0]", Old: Item{Name: "foo", Price: 100}, New: nil},
+				{Path: `Tags["env"]`, Old: "prod", New: nil},
+			},
+		},
+		{
+			name: "pointers",
+			in: args{
+				a: &Address{City: "Tokyo"},
+				b: &Address{City: "Osaka"},
+			},
+			want: []xgo.Change{
+				{Path: "City", Old: "Tokyo", New: "Osaka"},
+			},
+		},
+		{
+			name: "different types",
+			in: args{
+				a: Address{},
+				b: &Address{},
+			},
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := xgo.Diff(tt.in.a, tt.in.b)
+			if tt.err {
+				if err == nil {
+					t.Errorf("testing %s: should be error for %#v but not", tt.name, tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+		type Node struct {
+			Name   string
+			Parent *Node
+		}
+		a := &Node{Name: "a"}
+		a.Parent = a
+		b := &Node{Name: "b"}
+		b.Parent = b
+		got, err := xgo.Diff(a, b)
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff([]xgo.Change{{Path: "Name", Old: "a", New: "b"}}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+}
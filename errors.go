@@ -0,0 +1,102 @@
+package xgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// errUnsupported is the error for the types that cannot be copied
+	errUnsupported = errors.New("unsupported types")
+	// errUnmatched is the error of Strict for the destination field that no source field is copied to
+	errUnmatched = errors.New("no source field is copied to the field")
+	// errMaxDepth is the error of MaxDepth
+	errMaxDepth = errors.New("exceeds the max depth")
+	// errCycle is the error of ErrorOnCycle
+	errCycle = errors.New("cycle detected")
+	// errRequired is the error of the required option of the copier tag for the source field that has the zero value
+	errRequired = errors.New("the required field has the zero value")
+)
+
+// CopyError is the error of copying a value, it has the path to the value like Orders[3].Items["sku"].Price
+type CopyError struct {
+	Path    string
+	SrcType reflect.Type
+	DstType reflect.Type
+	Err     error
+}
+
+func (e *CopyError) Error() string {
+	var b strings.Builder
+	if e.Path != "" {
+		b.WriteString(e.Path)
+		b.WriteString(": ")
+	}
+	// the source type is nil for an unmatched destination field
+	if e.SrcType != nil {
+		fmt.Fprintf(&b, "cannot copy %v to %v: ", e.SrcType, e.DstType)
+	}
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+func (e *CopyError) Unwrap() error {
+	return e.Err
+}
+
+// pathStep is a struct field, a slice index or a map key
+type pathStep struct {
+	name  string
+	index int
+	key   reflect.Value
+}
+
+func fieldStep(name string) pathStep {
+	return pathStep{name: name, index: -1}
+}
+
+func indexStep(i int) pathStep {
+	return pathStep{index: i}
+}
+
+func keyStep(key reflect.Value) pathStep {
+	return pathStep{index: -1, key: key}
+}
+
+func (s *copyState) push(step pathStep) {
+	s.path = append(s.path, step)
+}
+
+func (s *copyState) pop() {
+	s.path = s.path[:len(s.path)-1]
+}
+
+// pathString formats the path, it is called only when an error occurs
+func (s *copyState) pathString() string {
+	return formatPath(s.path)
+}
+
+// formatPath formats the path like Orders[3].Items["sku"].Price
+func formatPath(path []pathStep) string {
+	var b strings.Builder
+	for _, step := range path {
+		switch {
+		case step.key.IsValid():
+			if step.key.Kind() == reflect.String {
+				fmt.Fprintf(&b, "[%q]", step.key.String())
+			} else {
+				fmt.Fprintf(&b, "[%v]", step.key)
+			}
+		case step.index >= 0:
+			fmt.Fprintf(&b, "[%d]", step.index)
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(step.name)
+		}
+	}
+	return b.String()
+}
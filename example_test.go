@@ -68,6 +68,30 @@ func ExampleDeepCopy_slice() {
 	// Output: ToModel object: &[{xxxx1 R2D2} {xxxx2 C3PO}]
 }
 
+func ExampleCopier() {
+	type FromModel struct {
+		ID   string `copier:"Id"`
+		Name string
+	}
+	type ToModel struct {
+		Id   string
+		Name string
+	}
+
+	// the mapping is checked when the copier is created
+	copier, err := xgo.NewCopier[FromModel, ToModel]()
+	if err != nil {
+		// handles error
+	}
+	to, err := copier.Copy(FromModel{ID: "xxxx", Name: "R2D2"})
+	if err != nil {
+		// handles error
+	}
+	fmt.Println("ToModel object:", to)
+
+	// Output: ToModel object: {xxxx R2D2}
+}
+
 func ExampleContains() {
 	// slice of int32
 	containsInt32 := xgo.Contains([]int32{1, 2, 3, 4, 5}, 3)
@@ -0,0 +1,155 @@
+package xgo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Report is the field mapping that DeepCopy uses for a source struct type and a destination struct type.
+// The fields of the nested structs are listed with the dotted paths like Address.City,
+// and the elements of slices and maps like Items[].Price.
+type Report struct {
+	// Fields are the source fields and the destination fields they are copied to
+	Fields []FieldMapping
+	// UnmappedSource are the exported source fields that are not copied
+	UnmappedSource []string
+	// UnsetDestination are the exported destination fields that no source field is copied to
+	UnsetDestination []string
+}
+
+// FieldMapping is a source field and the destination field it is copied to
+type FieldMapping struct {
+	Src string
+	Dst string
+	// ByTag reports whether the fields are matched by the copier tag
+	ByTag bool
+	// Converter is how the value is copied:
+	// "converter" for a converter of RegisterConverter or WithConverter,
+	// "assign", "convert" for a conversion of the types, "time" for the time conversions,
+	// "interface" for an interface source or destination, "number" for CheckedNumbers and RuneStrings,
+	// "text" for the strings parsed to the values and formatted from them,
+	// "deep copy" for structs, pointers, slices and maps,
+	// and "" when only a custom setter can copy it.
+	Converter string
+}
+
+// Explain returns the field mapping that DeepCopy with the options uses to copy src to dst,
+// src and dst are struct types or pointers to them
+func Explain(src, dst reflect.Type, opts ...Option) (*Report, error) {
+	s, d := indirectType(src), indirectType(dst)
+	if s.Kind() != reflect.Struct || d.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot explain %v to %v: %w", src, dst, errUnsupported)
+	}
+
+	e := &explainer{opts: newOptions(opts), report: &Report{}, visited: map[typePair]bool{}}
+	e.explain(s, d, "", "")
+	return e.report, nil
+}
+
+// explainer walks the nested struct types and builds the report
+type explainer struct {
+	opts    options
+	report  *Report
+	visited map[typePair]bool
+}
+
+// explain adds the fields of the struct types to the report, the prefixes are the paths of the structs
+func (e *explainer) explain(src, dst reflect.Type, srcPrefix, dstPrefix string) {
+	key := typePair{src, dst}
+	if e.visited[key] {
+		return
+	}
+	e.visited[key] = true
+	defer delete(e.visited, key)
+
+	plan := getPlan(src, dst, &e.opts)
+	var nested []fieldPlan
+	for _, f := range plan.fields {
+		srcField, dstField := src.FieldByIndex(f.srcIndex), dst.FieldByIndex(f.dstIndex)
+		conv := e.converter(srcField.Type, dstField.Type)
+		switch {
+		case f.shallow && srcField.Type.AssignableTo(dstField.Type):
+			conv = "assign"
+		case f.convert == nil && (conv == "assign" || conv == "convert"):
+			// the deep option of the copier tag
+			conv = "deep copy"
+		}
+		e.report.Fields = append(e.report.Fields, FieldMapping{
+			Src:       srcPrefix + f.name,
+			Dst:       dstPrefix + f.dstName,
+			ByTag:     f.byTag,
+			Converter: conv,
+		})
+		if conv == "deep copy" {
+			nested = append(nested, f)
+		}
+	}
+	for _, f := range plan.unmapped {
+		e.report.UnmappedSource = append(e.report.UnmappedSource, srcPrefix+f.Name)
+	}
+	for _, f := range plan.unmatched {
+		e.report.UnsetDestination = append(e.report.UnsetDestination, dstPrefix+f.Name)
+	}
+
+	// the fields of a struct are followed by the fields of the nested structs
+	for _, f := range nested {
+		srcField, dstField := src.FieldByIndex(f.srcIndex), dst.FieldByIndex(f.dstIndex)
+		e.explainElem(srcField.Type, dstField.Type, srcPrefix+f.name, dstPrefix+f.dstName)
+	}
+}
+
+// explainElem explains the nested struct types of a field, the names are the paths of the fields
+func (e *explainer) explainElem(src, dst reflect.Type, srcName, dstName string) {
+	s, d := indirectType(src), indirectType(dst)
+	switch {
+	case s.Kind() == reflect.Struct && d.Kind() == reflect.Struct:
+		e.explain(s, d, srcName+".", dstName+".")
+	case isList(s.Kind()) && isList(d.Kind()),
+		s.Kind() == reflect.Map && d.Kind() == reflect.Map:
+		if e.converter(s.Elem(), d.Elem()) == "deep copy" {
+			e.explainElem(s.Elem(), d.Elem(), srcName+"[]", dstName+"[]")
+		}
+	}
+}
+
+// converter describes how a value of src is copied to dst, see FieldMapping.Converter
+func (e *explainer) converter(src, dst reflect.Type) string {
+	key := typePair{src, dst}
+	if _, ok := e.opts.converters[key]; ok {
+		return "converter"
+	}
+	if _, ok := lookupConverter(src, dst); ok {
+		return "converter"
+	}
+	if src.Kind() == reflect.Interface || dst.Kind() == reflect.Interface {
+		return "interface"
+	}
+	if e.opts.numbers(src, dst) {
+		return "number"
+	}
+	if !e.opts.merges(src, dst) {
+		if src == dst {
+			return "assign"
+		}
+		if isConvertible(src, dst) {
+			return "convert"
+		}
+	}
+	if isTimePair(src, dst) {
+		return "time"
+	}
+	if isTextPair(src, dst) {
+		return "text"
+	}
+
+	s, d := indirectType(src), indirectType(dst)
+	switch {
+	case s.Kind() == reflect.Struct && d.Kind() == reflect.Struct,
+		isList(s.Kind()) && isList(d.Kind()),
+		s.Kind() == reflect.Map && d.Kind() == reflect.Map,
+		s.Kind() == reflect.Struct && isStringKeyMap(d),
+		isStringKeyMap(s) && d.Kind() == reflect.Struct:
+		return "deep copy"
+	}
+	return ""
+}
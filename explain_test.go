@@ -0,0 +1,182 @@
+package xgo_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo"
+)
+
+func TestExplain(t *testing.T) {
+	type Address struct {
+		City    string
+		Country string
+	}
+	type AddressDTO struct {
+		City string
+		Zip  string
+	}
+	type Item struct {
+		Name  string
+		Price int32
+	}
+	type ItemDTO struct {
+		Name  string
+		Price int64
+		Note  string
+	}
+	type Base struct {
+		ID string `copier:"Id"`
+	}
+	type User struct {
+		Base
+		Name      string
+		Nickname  string
+		Rank      string
+		Address   *Address
+		Items     []Item
+		CreatedAt time.Time
+		secret    string
+	}
+	type UserDTO struct {
+		Id        string
+		Name      string
+		Nick      string
+		Rank      int
+		Address   AddressDTO
+		Items     []*ItemDTO
+		CreatedAt string
+		Extra     interface{}
+	}
+
+	type args struct {
+		src  reflect.Type
+		dst  reflect.Type
+		opts []xgo.Option
+	}
+	tests := []struct {
+		name string
+		in   args
+		want *xgo.Report
+		err  bool
+	}{
+		{
+			name: "nested structs",
+			in: args{
+				src: reflect.TypeOf(User{}),
+				dst: reflect.TypeOf(&UserDTO{}),
+			},
+			want: &xgo.Report{
+				Fields: []xgo.FieldMapping{
+					{Src: "ID", Dst: "Id", ByTag: true, Converter: "assign"},
+					{Src: "Name", Dst: "Name", Converter: "assign"},
+					{Src: "Rank", Dst: "Rank", Converter: "text"},
+					{Src: "Address", Dst: "Address", Converter: "deep copy"},
+					{Src: "Items", Dst: "Items", Converter: "deep copy"},
+					{Src: "CreatedAt", Dst: "CreatedAt", Converter: "time"},
+					{Src: "Address.City", Dst: "Address.City", Converter: "assign"},
+					{Src: "Items[].Name", Dst: "Items[].Name", Converter: "assign"},
+					{Src: "Items[].Price", Dst: "Items[].Price", Converter: "convert"},
+				},
+				UnmappedSource:   []string{"Nickname", "Address.Country"},
+				UnsetDestination: []string{"Nick", "Extra", "Address.Zip", "Items[].Note"},
+			},
+		},
+		{
+			name: "converter",
+			in: args{
+				src:  reflect.TypeOf(User{}),
+				dst:  reflect.TypeOf(UserDTO{}),
+				opts: []xgo.Option{xgo.WithConverter(strconv.Atoi)},
+			},
+			want: &xgo.Report{
+				Fields: []xgo.FieldMapping{
+					{Src: "ID", Dst: "Id", ByTag: true, Converter: "assign"},
+					{Src: "Name", Dst: "Name", Converter: "assign"},
+					{Src: "Rank", Dst: "Rank", Converter: "converter"},
+					{Src: "Address", Dst: "Address", Converter: "deep copy"},
+					{Src: "Items", Dst: "Items", Converter: "deep copy"},
+					{Src: "CreatedAt", Dst: "CreatedAt", Converter: "time"},
+					{Src: "Address.City", Dst: "Address.City", Converter: "assign"},
+					{Src: "Items[].Name", Dst: "Items[].Name", Converter: "assign"},
+					{Src: "Items[].Price", Dst: "Items[].Price", Converter: "convert"},
+				},
+				UnmappedSource:   []string{"Nickname", "Address.Country"},
+				UnsetDestination: []string{"Nick", "Extra", "Address.Zip", "Items[].Note"},
+			},
+		},
+		{
+			name: "not struct",
+			in: args{
+				src: reflect.TypeOf([]User{}),
+				dst: reflect.TypeOf([]UserDTO{}),
+			},
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := xgo.Explain(tt.in.src, tt.in.dst, tt.in.opts...)
+			if tt.err {
+				if err == nil {
+					t.Errorf("testing %s: should be error for %v but not", tt.name, tt.in.src)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %v but: %v", tt.name, tt.in.src, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+
+	t.Run("interface source", func(t *testing.T) {
+		t.Parallel()
+		type Src struct {
+			V interface{}
+		}
+		type Dst struct {
+			V int
+		}
+		got, err := xgo.Explain(reflect.TypeOf(Src{}), reflect.TypeOf(Dst{}))
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		want := &xgo.Report{
+			Fields: []xgo.FieldMapping{{Src: "V", Dst: "V", Converter: "interface"}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("recursive type", func(t *testing.T) {
+		t.Parallel()
+		type Node struct {
+			Name     string
+			Children []*Node
+		}
+		got, err := xgo.Explain(reflect.TypeOf(Node{}), reflect.TypeOf(Node{}))
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		want := &xgo.Report{
+			Fields: []xgo.FieldMapping{
+				{Src: "Name", Dst: "Name", Converter: "assign"},
+				{Src: "Children", Dst: "Children", Converter: "assign"},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+}
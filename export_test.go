@@ -0,0 +1,17 @@
+package xgo
+
+// ResetPlanCache clears the cached copy plans, conversions and key fields
+func ResetPlanCache() {
+	planCache.Range(func(k, _ any) bool {
+		planCache.Delete(k)
+		return true
+	})
+	convertCache.Range(func(k, _ any) bool {
+		convertCache.Delete(k)
+		return true
+	})
+	keyFieldCache.Range(func(k, _ any) bool {
+		keyFieldCache.Delete(k)
+		return true
+	})
+}
@@ -0,0 +1,87 @@
+// Package tag parses the copier tag that xgo.DeepCopy and xgo-copygen share.
+package tag
+
+import "strings"
+
+// Key is the key of the copier tag
+const Key = "copier"
+
+// Tag is the copier tag of a field for the other struct type
+type Tag struct {
+	// Name is the field name of the other struct, it is empty if the field is not renamed
+	Name string
+	// Skip reports whether the field is not copied, e.g. copier:"-"
+	Skip bool
+	// OmitEmpty does not copy the zero value
+	OmitEmpty bool
+	// Required returns an error when the source field has the zero value or no field is copied to the destination field
+	Required bool
+	// Deep copies the pointers, slices, maps and structs of the same types instead of assigning them
+	Deep bool
+	// Shallow assigns the value as it is when the types are assignable
+	Shallow bool
+	// Epoch is the unit of the number copied from and to time.Time,
+	// one of "unix", "unixmilli", "unixmicro" and "unixnano", it is empty for the default
+	Epoch string
+}
+
+// Parse parses the copier tag for the other struct type, the qualifiers identify the type like "pb.User", "User" and "pb",
+// the package qualifier is the last element of the import path.
+// The tag is the clauses separated by ";", a clause is the name and the options separated by ",".
+// The clause like "to=pb.UserId,omitempty" applies only to the type of the qualifier followed by ".",
+// the clause like "Id,omitempty" or "name=Id,omitempty" applies to the other types.
+// The name is a field name or a dotted path to a nested field like Address.City.
+func Parse(s string, qualifiers ...string) Tag {
+	var (
+		def   Tag
+		found bool
+		best  Tag
+		rank  = len(qualifiers)
+	)
+	for _, clause := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(clause, "=")
+		if !ok {
+			key, value = "name", clause
+		}
+		switch strings.TrimSpace(key) {
+		case "name":
+			def = parseClause(value)
+		case "to", "from":
+			target, _, _ := strings.Cut(value, ",")
+			target = strings.TrimSpace(target)
+			for r, q := range qualifiers {
+				if r < rank && strings.HasPrefix(target, q+".") {
+					best, rank, found = parseClause(strings.TrimSpace(value)[len(q)+1:]), r, true
+				}
+			}
+		}
+	}
+	if found {
+		return best
+	}
+	return def
+}
+
+// parseClause parses the name and the options
+func parseClause(s string) Tag {
+	name, opts, _ := strings.Cut(s, ",")
+	t := Tag{Name: strings.TrimSpace(name)}
+	if t.Name == "-" {
+		return Tag{Skip: true}
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		switch strings.TrimSpace(opt) {
+		case "omitempty":
+			t.OmitEmpty = true
+		case "required":
+			t.Required = true
+		case "deep":
+			t.Deep = true
+		case "shallow":
+			t.Shallow = true
+		case "unix", "unixmilli", "unixmicro", "unixnano":
+			t.Epoch = strings.TrimSpace(opt)
+		}
+	}
+	return t
+}
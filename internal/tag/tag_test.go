@@ -0,0 +1,108 @@
+package tag_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo/internal/tag"
+)
+
+func TestParse(t *testing.T) {
+	type args struct {
+		tag        string
+		qualifiers []string
+	}
+	tests := []struct {
+		name string
+		in   args
+		want tag.Tag
+	}{
+		{
+			name: "name",
+			in:   args{tag: "Id"},
+			want: tag.Tag{Name: "Id"},
+		},
+		{
+			name: "name and options",
+			in:   args{tag: "Id,omitempty,required"},
+			want: tag.Tag{Name: "Id", OmitEmpty: true, Required: true},
+		},
+		{
+			name: "options only",
+			in:   args{tag: ",deep"},
+			want: tag.Tag{Deep: true},
+		},
+		{
+			name: "skip",
+			in:   args{tag: "-"},
+			want: tag.Tag{Skip: true},
+		},
+		{
+			name: "name key",
+			in:   args{tag: "name=Id,shallow"},
+			want: tag.Tag{Name: "Id", Shallow: true},
+		},
+		{
+			name: "target type",
+			in: args{
+				tag:        "name=Id;to=pb.UserId,required",
+				qualifiers: []string{"pb.User", "User", "pb"},
+			},
+			want: tag.Tag{Name: "UserId", Required: true},
+		},
+		{
+			name: "other type",
+			in: args{
+				tag:        "name=Id;to=pb.UserId",
+				qualifiers: []string{"model.UserDTO", "UserDTO", "model"},
+			},
+			want: tag.Tag{Name: "Id"},
+		},
+		{
+			name: "the most specific target",
+			in: args{
+				tag:        "to=pb.UserId;to=pb.User.ID;to=pb.Admin.AdminId",
+				qualifiers: []string{"pb.User", "User", "pb"},
+			},
+			want: tag.Tag{Name: "ID"},
+		},
+		{
+			name: "epoch unit",
+			in:   args{tag: ",unixmilli,omitempty"},
+			want: tag.Tag{OmitEmpty: true, Epoch: "unixmilli"},
+		},
+		{
+			name: "path",
+			in:   args{tag: "Address.City,omitempty"},
+			want: tag.Tag{Name: "Address.City", OmitEmpty: true},
+		},
+		{
+			name: "path for the target type",
+			in: args{
+				tag:        "Address.City;to=pb.User.Location.City",
+				qualifiers: []string{"pb.User", "User", "pb"},
+			},
+			want: tag.Tag{Name: "Location.City"},
+		},
+		{
+			name: "skip for the target type",
+			in: args{
+				tag:        "Id;to=pb.-",
+				qualifiers: []string{"pb.User", "User", "pb"},
+			},
+			want: tag.Tag{Skip: true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tag.Parse(tt.in.tag, tt.in.qualifiers...)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
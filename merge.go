@@ -0,0 +1,193 @@
+package xgo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type slicePolicyKind int
+
+const (
+	sliceReplace slicePolicyKind = iota
+	sliceAppend
+	sliceMergeByKey
+)
+
+// SlicePolicy decides how Merge copies a slice to the destination slice
+type SlicePolicy struct {
+	kind slicePolicyKind
+	// key is the field name of SliceMergeByKey
+	key string
+}
+
+var (
+	// SliceReplace replaces the destination slice with the source slice
+	SliceReplace = SlicePolicy{kind: sliceReplace}
+	// SliceAppend appends the source elements to the destination slice
+	SliceAppend = SlicePolicy{kind: sliceAppend}
+)
+
+// SliceMergeByKey merges the source elements to the destination elements that have the same value of the field,
+// the other source elements are appended. The elements are structs or pointers to structs.
+func SliceMergeByKey(field string) SlicePolicy {
+	return SlicePolicy{kind: sliceMergeByKey, key: field}
+}
+
+// isBlank determines whether the value is skipped by Merge
+func isBlank(v reflect.Value) bool {
+	if !v.CanInterface() {
+		// promoted through an unexported embedded struct
+		return v.IsZero()
+	}
+	return IsBlank(v)
+}
+
+// skip determines whether the source value is not copied to the destination
+func (c *deepCopier) skip(v reflect.Value) bool {
+	if c.opts.merge {
+		return isBlank(v)
+	}
+	return c.opts.ignoreEmpty && v.IsZero()
+}
+
+// mergeable reports whether Merge copies the struct type field by field,
+// the structs that have no exported fields like time.Time are copied as a whole
+func mergeable(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// merges determines whether Merge copies src to the existing value of dst instead of replacing it,
+// a registered converter replaces the value
+func (o *options) merges(src, dst reflect.Type) bool {
+	if !o.merge {
+		return false
+	}
+	if _, ok := lookupConverter(src, dst); ok {
+		return false
+	}
+	switch t := indirectType(dst); t.Kind() {
+	case reflect.Struct:
+		return mergeable(t)
+	case reflect.Map:
+		return true
+	case reflect.Slice:
+		return o.slicePolicy.kind != sliceReplace
+	}
+	return false
+}
+
+// mergeSlice copies the elements of src to the destination slice with the slice policy other than SliceReplace
+func (c *deepCopier) mergeSlice(src, dst reflect.Value) error {
+	if c.opts.slicePolicy.kind == sliceMergeByKey {
+		return c.mergeSliceByKey(src, dst)
+	}
+	return c.appendSlice(src, dst)
+}
+
+// appendSlice copies the elements of src after the elements of the destination slice
+func (c *deepCopier) appendSlice(src, dst reflect.Value) error {
+	n := dst.Len()
+	rv := reflect.MakeSlice(dst.Type(), n+src.Len(), n+src.Len())
+	reflect.Copy(rv, dst.Slice(0, n))
+
+	conv := getConvert(src.Type().Elem(), dst.Type().Elem())
+	for i := 0; i < src.Len(); i++ {
+		c.state.push(indexStep(i))
+		err := c.copyElem(src.Index(i), rv.Index(n+i), conv)
+		c.state.pop()
+		if err != nil {
+			return err
+		}
+	}
+	dst.Set(rv)
+	return nil
+}
+
+// mergeSliceByKey merges the source elements to the destination elements that have the same key,
+// the other source elements are appended
+func (c *deepCopier) mergeSliceByKey(src, dst reflect.Value) error {
+	key := c.opts.slicePolicy.key
+	srcElem, dstElem := indirectType(src.Type().Elem()), indirectType(dst.Type().Elem())
+	if srcElem.Kind() != reflect.Struct || dstElem.Kind() != reflect.Struct {
+		return c.fail(src.Type(), dst.Type(), fmt.Errorf("cannot merge the elements by key %s", key))
+	}
+	srcKey, ok := srcElem.FieldByName(key)
+	if !ok || !srcKey.IsExported() {
+		return c.fail(src.Type(), dst.Type(), fmt.Errorf("%v has no key field %s", srcElem, key))
+	}
+	dstKey, ok := dstElem.FieldByName(key)
+	if !ok || !dstKey.IsExported() {
+		return c.fail(src.Type(), dst.Type(), fmt.Errorf("%v has no key field %s", dstElem, key))
+	}
+	if !dstKey.Type.Comparable() || !srcKey.Type.ConvertibleTo(dstKey.Type) {
+		return c.fail(src.Type(), dst.Type(), fmt.Errorf("cannot compare the key field %s", key))
+	}
+
+	index := make(map[interface{}]int, dst.Len())
+	for i := 0; i < dst.Len(); i++ {
+		if k, ok := keyOf(dst.Index(i), dstKey); ok {
+			index[k.Interface()] = i
+		}
+	}
+
+	// the elements that have no matched destination element
+	var rest []int
+	conv := getConvert(src.Type().Elem(), dst.Type().Elem())
+	for i := 0; i < src.Len(); i++ {
+		k, ok := keyOf(src.Index(i), srcKey)
+		if !ok {
+			continue
+		}
+		j, ok := index[k.Convert(dstKey.Type).Interface()]
+		if !ok {
+			rest = append(rest, i)
+			continue
+		}
+		c.state.push(indexStep(i))
+		err := c.copyElem(src.Index(i), dst.Index(j), conv)
+		c.state.pop()
+		if err != nil {
+			return err
+		}
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+
+	n := dst.Len()
+	rv := reflect.MakeSlice(dst.Type(), n+len(rest), n+len(rest))
+	reflect.Copy(rv, dst)
+	for j, i := range rest {
+		c.state.push(indexStep(i))
+		err := c.copyElem(src.Index(i), rv.Index(n+j), conv)
+		c.state.pop()
+		if err != nil {
+			return err
+		}
+	}
+	dst.Set(rv)
+	return nil
+}
+
+// keyOf returns the key field of a struct or a pointer to a struct, a nil pointer has no key
+func keyOf(v reflect.Value, f reflect.StructField) (reflect.Value, bool) {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	k, err := v.FieldByIndexErr(f.Index)
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	return k, true
+}
@@ -0,0 +1,206 @@
+package xgo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo"
+)
+
+func TestMerge(t *testing.T) {
+	type Address struct {
+		City    string
+		ZipCode string
+	}
+	type Item struct {
+		ID       int
+		Name     string
+		Quantity int
+	}
+	type UserPatch struct {
+		Name      string
+		Age       *int
+		Active    *bool
+		Address   *Address
+		Items     []Item
+		Tags      []string
+		Labels    map[string]string
+		UpdatedAt time.Time
+	}
+	type User struct {
+		Name      string
+		Age       int
+		Active    bool
+		Address   Address
+		Items     []*Item
+		Tags      []string
+		Labels    map[string]string
+		UpdatedAt time.Time
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	user := func() *User {
+		return &User{
+			Name:    "R2D2",
+			Age:     30,
+			Active:  true,
+			Address: Address{City: "Tokyo", ZipCode: "100-0001"},
+			Items: []*Item{
+				{ID: 1, Name: "foo", Quantity: 1},
+				{ID: 2, Name: "bar", Quantity: 2},
+			},
+			Tags:   []string{"a"},
+			Labels: map[string]string{"env": "prod"},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		src    UserPatch
+		policy xgo.SlicePolicy
+		want   *User
+	}{
+		{
+			name:   "blank fields are skipped",
+			src:    UserPatch{},
+			policy: xgo.SliceReplace,
+			want:   user(),
+		},
+		{
+			name: "zero value of a pointer is copied",
+			src: UserPatch{
+				Name:      "C3PO",
+				Age:       xgo.ToPtr(0),
+				Active:    xgo.ToPtr(false),
+				UpdatedAt: now,
+			},
+			policy: xgo.SliceReplace,
+			want: func() *User {
+				u := user()
+				u.Name = "C3PO"
+				u.Age = 0
+				u.Active = false
+				u.UpdatedAt = now
+				return u
+			}(),
+		},
+		{
+			name: "nested struct and map are merged",
+			src: UserPatch{
+				Address: &Address{ZipCode: "150-0001"},
+				Labels:  map[string]string{"team": "droid"},
+			},
+			policy: xgo.SliceReplace,
+			want: func() *User {
+				u := user()
+				u.Address.ZipCode = "150-0001"
+				u.Labels["team"] = "droid"
+				return u
+			}(),
+		},
+		{
+			name: "replace slices",
+			src: UserPatch{
+				Items: []Item{{ID: 3, Name: "baz"}},
+				Tags:  []string{},
+			},
+			policy: xgo.SliceReplace,
+			want: func() *User {
+				u := user()
+				u.Items = []*Item{{ID: 3, Name: "baz"}}
+				u.Tags = []string{}
+				return u
+			}(),
+		},
+		{
+			name: "append slices",
+			src: UserPatch{
+				Items: []Item{{ID: 3, Name: "baz"}},
+				Tags:  []string{"b"},
+			},
+			policy: xgo.SliceAppend,
+			want: func() *User {
+				u := user()
+				u.Items = append(u.Items, &Item{ID: 3, Name: "baz"})
+				u.Tags = []string{"a", "b"}
+				return u
+			}(),
+		},
+		{
+			name: "merge slices by key",
+			src: UserPatch{
+				Items: []Item{{ID: 2, Quantity: 5}, {ID: 3, Name: "baz"}},
+			},
+			policy: xgo.SliceMergeByKey("ID"),
+			want: func() *User {
+				u := user()
+				u.Items[1].Quantity = 5
+				u.Items = append(u.Items, &Item{ID: 3, Name: "baz"})
+				return u
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := user()
+			err := xgo.DeepCopy(tt.src, got, xgo.Merge(tt.policy))
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.src, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+
+	t.Run("pointers are merged in place", func(t *testing.T) {
+		t.Parallel()
+		type Profile struct {
+			Address *Address
+		}
+		addr := &Address{City: "Tokyo", ZipCode: "100-0001"}
+		got := &Profile{Address: addr}
+		err := xgo.DeepCopy(Profile{Address: &Address{City: "Osaka"}}, got, xgo.Merge(xgo.SliceReplace))
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if got.Address != addr {
+			t.Errorf("the pointer should be kept")
+		}
+		if diff := cmp.Diff(&Address{City: "Osaka", ZipCode: "100-0001"}, addr); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("top-level slice", func(t *testing.T) {
+		t.Parallel()
+		got := []Item{{ID: 1, Name: "foo", Quantity: 1}}
+		err := xgo.DeepCopy([]*Item{{ID: 1, Quantity: 3}, {ID: 2, Name: "bar"}}, &got, xgo.Merge(xgo.SliceMergeByKey("ID")))
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		want := []Item{{ID: 1, Name: "foo", Quantity: 3}, {ID: 2, Name: "bar"}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("no key field", func(t *testing.T) {
+		t.Parallel()
+		got := user()
+		err := xgo.DeepCopy(UserPatch{Items: []Item{{ID: 1}}}, got, xgo.Merge(xgo.SliceMergeByKey("SKU")))
+		var copyErr *xgo.CopyError
+		if !errors.As(err, &copyErr) {
+			t.Fatalf("should be CopyError but: %v", err)
+		}
+		if copyErr.Path != "Items" {
+			t.Errorf("the path should be Items but: %s", copyErr.Path)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package xgo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// NameMatcher matches the field names that follow different naming conventions,
+// the names match when they have the same key
+type NameMatcher struct {
+	id  uint64
+	key func(name string) string
+}
+
+// matcherID numbers the name matchers for the key of planCache
+var matcherID atomic.Uint64
+
+// NewNameMatcher returns the NameMatcher that matches the names by the key function,
+// e.g. strings.ToLower matches the names ignoring case.
+// The copy plans are cached for each matcher, so create it once like a package-level variable
+// instead of on each copy.
+func NewNameMatcher(key func(name string) string) *NameMatcher {
+	return &NameMatcher{id: matcherID.Add(1), key: key}
+}
+
+var (
+	// MatchCaseInsensitive matches the names ignoring case like UserID and Userid
+	MatchCaseInsensitive = NewNameMatcher(strings.ToLower)
+	// MatchInitialisms matches the initialisms and the capitalized words like UserID and UserId, URLPath and UrlPath
+	MatchInitialisms = NewNameMatcher(func(name string) string {
+		return strings.Join(splitWords(name, false), "_")
+	})
+	// MatchSnakeCase matches snake_case and CamelCase like user_id, UserID and UserId
+	MatchSnakeCase = NewNameMatcher(func(name string) string {
+		return strings.Join(splitWords(name, true), "_")
+	})
+)
+
+// matches reports whether the names have the same key
+func (m *NameMatcher) matches(a, b string) bool {
+	return m.key(a) == m.key(b)
+}
+
+// splitWords splits the CamelCase name into the lower case words, an initialism like ID is a word.
+// The name is split at "_" too when snake is true.
+func splitWords(name string, snake bool) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	runes := []rune(name)
+	for i, r := range runes {
+		if r == '_' && snake {
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// the boundary of a word like userID, or the end of an initialism like URLPath
+			if !unicode.IsUpper(prev) || next {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, unicode.ToLower(r))
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// matcherKey identifies the name matchers in the key of planCache by their ids,
+// an address could be reused by another matcher after the garbage collection
+func matcherKey(matchers []*NameMatcher) string {
+	var b strings.Builder
+	for _, m := range matchers {
+		fmt.Fprintf(&b, "%d;", m.id)
+	}
+	return b.String()
+}
+
+// fieldByMatchers returns the exported field that the name matchers match in order, when no field has the exact name
+func fieldByMatchers(t reflect.Type, name string, matchers []*NameMatcher) (reflect.StructField, bool) {
+	for _, m := range matchers {
+		f, ok := t.FieldByNameFunc(func(s string) bool {
+			return IsFirstUpper(s) && m.matches(s, name)
+		})
+		if ok {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// mapIndexMatch returns the element of the key that the name matchers match in order,
+// the zero Value if there is no such key
+func mapIndexMatch(m reflect.Value, key string, matchers []*NameMatcher) reflect.Value {
+	for _, nm := range matchers {
+		iter := m.MapRange()
+		for iter.Next() {
+			if nm.matches(iter.Key().String(), key) {
+				return iter.Value()
+			}
+		}
+	}
+	return reflect.Value{}
+}
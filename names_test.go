@@ -0,0 +1,162 @@
+package xgo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo"
+)
+
+func TestDeepCopy_nameMatcher(t *testing.T) {
+	type User struct {
+		UserID    string
+		AvatarURL string
+		Userid    string
+	}
+	type UserPB struct {
+		UserId    string
+		AvatarUrl string
+	}
+	type UserRow struct {
+		User_Id    string
+		Avatar_Url string
+	}
+	type Account struct {
+		AccountName string
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+		opts []xgo.Option
+	}
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+	}{
+		{
+			name: "exact match by default",
+			in: args{
+				src:  User{UserID: "xxxx", AvatarURL: "https://example.com"},
+				dest: &UserPB{},
+			},
+			want: &UserPB{},
+		},
+		{
+			name: "initialisms",
+			in: args{
+				src:  User{UserID: "xxxx", AvatarURL: "https://example.com", Userid: "yyyy"},
+				dest: &UserPB{},
+				opts: []xgo.Option{xgo.WithNameMatcher(xgo.MatchInitialisms)},
+			},
+			want: &UserPB{UserId: "xxxx", AvatarUrl: "https://example.com"},
+		},
+		{
+			name: "initialisms to struct",
+			in: args{
+				src:  UserPB{UserId: "xxxx", AvatarUrl: "https://example.com"},
+				dest: &User{},
+				opts: []xgo.Option{xgo.WithNameMatcher(xgo.MatchInitialisms)},
+			},
+			want: &User{UserID: "xxxx", AvatarURL: "https://example.com"},
+		},
+		{
+			name: "snake case",
+			in: args{
+				src:  User{UserID: "xxxx", AvatarURL: "https://example.com"},
+				dest: &UserRow{},
+				opts: []xgo.Option{xgo.WithNameMatcher(xgo.MatchSnakeCase)},
+			},
+			want: &UserRow{User_Id: "xxxx", Avatar_Url: "https://example.com"},
+		},
+		{
+			name: "snake case from map",
+			in: args{
+				src:  map[string]interface{}{"user_id": "xxxx", "avatar_url": "https://example.com"},
+				dest: &User{},
+				opts: []xgo.Option{xgo.WithNameMatcher(xgo.MatchSnakeCase)},
+			},
+			want: &User{UserID: "xxxx", AvatarURL: "https://example.com"},
+		},
+		{
+			name: "matchers in order",
+			in: args{
+				src:  UserPB{UserId: "xxxx"},
+				dest: &User{},
+				opts: []xgo.Option{xgo.WithNameMatcher(xgo.MatchInitialisms, xgo.MatchCaseInsensitive)},
+			},
+			want: &User{UserID: "xxxx"},
+		},
+		{
+			name: "custom matcher",
+			in: args{
+				src:  map[string]interface{}{"account-name": "R2D2"},
+				dest: &Account{},
+				opts: []xgo.Option{xgo.WithNameMatcher(xgo.NewNameMatcher(func(name string) string {
+					return strings.ToLower(strings.ReplaceAll(name, "-", ""))
+				}))},
+			},
+			want: &Account{AccountName: "R2D2"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.in.src, tt.in.dest, tt.in.opts...)
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, tt.in.dest); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+
+	t.Run("unexported field is not matched", func(t *testing.T) {
+		t.Parallel()
+		type Src struct {
+			Name string
+		}
+		type Dst struct {
+			name string
+		}
+		got := &Dst{}
+		if err := xgo.DeepCopy(&Src{Name: "a"}, got, xgo.CaseInsensitive()); err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if got.name != "" {
+			t.Errorf("should not copy to the unexported field: %q", got.name)
+		}
+	})
+
+	t.Run("each matcher has its own plans", func(t *testing.T) {
+		t.Parallel()
+		type Src struct {
+			UserID string
+		}
+		type Dst struct {
+			Userid string
+		}
+		for i, tt := range []struct {
+			matcher *xgo.NameMatcher
+			want    Dst
+		}{
+			{xgo.NewNameMatcher(strings.ToLower), Dst{Userid: "a"}},
+			{xgo.NewNameMatcher(strings.ToUpper), Dst{Userid: "a"}},
+			{xgo.NewNameMatcher(func(name string) string { return name }), Dst{}},
+		} {
+			got := Dst{}
+			if err := xgo.DeepCopy(Src{UserID: "a"}, &got, xgo.WithNameMatcher(tt.matcher)); err != nil {
+				t.Errorf("should not be error but: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("testing %d mismatch (-want +got):\n%s\n", i, diff)
+			}
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package xgo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// errNumberRange is the error of CheckedNumbers for the number that does not fit the destination type
+var errNumberRange = errors.New("the number does not fit the destination type")
+
+// numbers determines whether convertNumber copies src to dst with the options CheckedNumbers and RuneStrings,
+// the converters of WithConverter and RegisterConverter take priority
+func (o *options) numbers(src, dst reflect.Type) bool {
+	s, d := indirectType(src), indirectType(dst)
+	var ok bool
+	switch {
+	case o.checkedNumbers && isNumber(s.Kind()) && isNumber(d.Kind()):
+		ok = s.Kind() != d.Kind()
+	case o.runeStrings && isInteger(s.Kind()) && d.Kind() == reflect.String:
+		// the text methods like time.Duration.String take priority
+		ok = !hasTextMethod(s, textMarshalerType, stringerType)
+	}
+	return ok && !o.hasConverter(src, dst)
+}
+
+// hasConverter determines whether a converter of WithConverter or RegisterConverter copies src to dst
+func (o *options) hasConverter(src, dst reflect.Type) bool {
+	if _, ok := o.converters[typePair{src, dst}]; ok {
+		return true
+	}
+	_, ok := lookupConverter(src, dst)
+	return ok
+}
+
+// convertNumber converts a number with the options CheckedNumbers and RuneStrings, it reports whether the value was handled
+func (c *deepCopier) convertNumber(src, dst reflect.Value) (bool, error) {
+	if !c.opts.numbers(src.Type(), dst.Type()) {
+		return false, nil
+	}
+	err := setIndirect(src, dst, func(src, dst reflect.Value) error {
+		if dst.Kind() == reflect.String {
+			// RuneStrings converts the integer like string(rune(i))
+			dst.Set(src.Convert(dst.Type()))
+			return nil
+		}
+		return setNumber(src, dst, c.opts.checkedNumbers)
+	})
+	if err != nil {
+		return true, c.fail(src.Type(), dst.Type(), err)
+	}
+	return true, nil
+}
+
+// setNumber sets the number of src to dst, the integers and the strings are the decimal numbers
+func setNumber(src, dst reflect.Value, checked bool) error {
+	switch {
+	case dst.Kind() == reflect.String:
+		if src.CanInt() {
+			dst.SetString(strconv.FormatInt(src.Int(), 10))
+		} else {
+			dst.SetString(strconv.FormatUint(src.Uint(), 10))
+		}
+		return nil
+	case src.Kind() == reflect.String:
+		if dst.CanInt() {
+			n, err := strconv.ParseInt(src.String(), 10, dst.Type().Bits())
+			if err != nil {
+				return err
+			}
+			dst.SetInt(n)
+			return nil
+		}
+		n, err := strconv.ParseUint(src.String(), 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(n)
+		return nil
+	}
+
+	if checked && !fits(src, dst.Type()) {
+		return fmt.Errorf("%v: %w", src, errNumberRange)
+	}
+	dst.Set(src.Convert(dst.Type()))
+	return nil
+}
+
+// fits reports whether the number is converted to the type without overflow or loss of precision.
+// A float is converted to a smaller float when it keeps the value, the infinities and NaN keep it.
+func fits(v reflect.Value, t reflect.Type) bool {
+	z := reflect.Zero(t)
+	switch {
+	case v.CanInt():
+		n := v.Int()
+		switch {
+		case z.CanInt():
+			return !z.OverflowInt(n)
+		case z.CanUint():
+			return n >= 0 && !z.OverflowUint(uint64(n))
+		}
+		f := v.Convert(t).Float()
+		return f < math.MaxInt64 && int64(f) == n
+	case v.CanUint():
+		n := v.Uint()
+		switch {
+		case z.CanInt():
+			return n <= math.MaxInt64 && !z.OverflowInt(int64(n))
+		case z.CanUint():
+			return !z.OverflowUint(n)
+		}
+		f := v.Convert(t).Float()
+		return f < math.MaxUint64 && uint64(f) == n
+	}
+
+	f := v.Float()
+	switch {
+	case z.CanInt():
+		return f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !z.OverflowInt(int64(f))
+	case z.CanUint():
+		return f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !z.OverflowUint(uint64(f))
+	}
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return true
+	}
+	return !z.OverflowFloat(f) && v.Convert(t).Float() == f
+}
+
+// isNumber determines whether the kind is an integer or a float
+func isNumber(k reflect.Kind) bool {
+	return isInteger(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+// isInteger determines whether the kind is a signed or an unsigned integer
+func isInteger(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
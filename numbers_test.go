@@ -0,0 +1,199 @@
+package xgo_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo"
+)
+
+func TestDeepCopy_numbers(t *testing.T) {
+	type Int64Model struct {
+		Value int64
+	}
+	type Uint8Model struct {
+		Value uint8
+	}
+	type Float64Model struct {
+		Value float64
+	}
+	type IntModel struct {
+		Value int
+	}
+	type Float32Model struct {
+		Value float32
+	}
+	type StringModel struct {
+		Value string
+	}
+	type PtrModel struct {
+		Value *uint8
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+		opts []xgo.Option
+	}
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+		err  bool
+	}{
+		{
+			name: "truncated by default",
+			in:   args{src: Int64Model{Value: 300}, dest: &Uint8Model{}},
+			want: &Uint8Model{Value: 44},
+		},
+		{
+			name: "checked",
+			in:   args{src: Int64Model{Value: 255}, dest: &Uint8Model{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			want: &Uint8Model{Value: 255},
+		},
+		{
+			name: "checked overflow",
+			in:   args{src: Int64Model{Value: 300}, dest: &Uint8Model{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			err:  true,
+		},
+		{
+			name: "checked negative to unsigned",
+			in:   args{src: Int64Model{Value: -1}, dest: &Uint8Model{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			err:  true,
+		},
+		{
+			name: "checked float to int",
+			in:   args{src: Float64Model{Value: 3}, dest: &IntModel{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			want: &IntModel{Value: 3},
+		},
+		{
+			name: "checked fraction",
+			in:   args{src: Float64Model{Value: 1.5}, dest: &IntModel{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			err:  true,
+		},
+		{
+			name: "checked int to float",
+			in:   args{src: Int64Model{Value: 1<<53 + 1}, dest: &Float64Model{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			err:  true,
+		},
+		{
+			name: "checked float overflow",
+			in:   args{src: Float64Model{Value: math.MaxFloat64}, dest: &Float32Model{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			err:  true,
+		},
+		{
+			name: "checked float precision",
+			in:   args{src: Float64Model{Value: 0.1}, dest: &Float32Model{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			err:  true,
+		},
+		{
+			name: "checked float fits",
+			in:   args{src: Float64Model{Value: 1.5}, dest: &Float32Model{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			want: &Float32Model{Value: 1.5},
+		},
+		{
+			name: "checked to pointer",
+			in:   args{src: Int64Model{Value: 300}, dest: &PtrModel{}, opts: []xgo.Option{xgo.CheckedNumbers()}},
+			err:  true,
+		},
+		{
+			name: "decimal string by default",
+			in:   args{src: Int64Model{Value: 65}, dest: &StringModel{}},
+			want: &StringModel{Value: "65"},
+		},
+		{
+			name: "rune string",
+			in:   args{src: Int64Model{Value: 65}, dest: &StringModel{}, opts: []xgo.Option{xgo.RuneStrings()}},
+			want: &StringModel{Value: "A"},
+		},
+		{
+			name: "parse decimal string",
+			in:   args{src: StringModel{Value: "-65"}, dest: &Int64Model{}},
+			want: &Int64Model{Value: -65},
+		},
+		{
+			name: "parse decimal string to pointer",
+			in:   args{src: StringModel{Value: "255"}, dest: &PtrModel{}},
+			want: &PtrModel{Value: xgo.ToPtr(uint8(255))},
+		},
+		{
+			name: "parse decimal string overflow",
+			in:   args{src: StringModel{Value: "300"}, dest: &Uint8Model{}},
+			err:  true,
+		},
+		{
+			name: "parse invalid decimal string",
+			in:   args{src: StringModel{Value: "R2D2"}, dest: &Int64Model{}},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.in.src, tt.in.dest, tt.in.opts...)
+			if tt.err {
+				var copyErr *xgo.CopyError
+				if !errors.As(err, &copyErr) {
+					t.Errorf("testing %s: should be CopyError but: %v", tt.name, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, tt.in.dest); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+
+	t.Run("converters take priority", func(t *testing.T) {
+		type Cents int64
+		type Price struct {
+			Value Cents
+		}
+		type PriceDTO struct {
+			Value string
+		}
+		type SmallPrice struct {
+			Value uint8
+		}
+		xgo.RegisterConverter(func(c Cents) (string, error) {
+			return fmt.Sprintf("$%d.%02d", c/100, c%100), nil
+		})
+		xgo.RegisterConverter(func(c Cents) (uint8, error) {
+			return uint8(c / 100), nil
+		})
+
+		got := &PriceDTO{}
+		if err := xgo.DeepCopy(Price{Value: 1234}, got, xgo.RuneStrings()); err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(&PriceDTO{Value: "$12.34"}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+
+		small := &SmallPrice{}
+		if err := xgo.DeepCopy(Price{Value: 1234}, small, xgo.CheckedNumbers()); err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(&SmallPrice{Value: 12}, small); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("error path", func(t *testing.T) {
+		t.Parallel()
+		err := xgo.DeepCopy(Int64Model{Value: 300}, &Uint8Model{}, xgo.CheckedNumbers())
+		want := "Value: cannot copy int64 to uint8: 300: the number does not fit the destination type"
+		if err == nil || err.Error() != want {
+			t.Errorf("should be error %q but: %v", want, err)
+		}
+	})
+}
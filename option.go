@@ -0,0 +1,206 @@
+package xgo
+
+import (
+	"reflect"
+	"time"
+)
+
+// Option configures DeepCopy, the options apply to the nested copies too
+type Option func(*options)
+
+type options struct {
+	collectErrors bool
+	ignoreEmpty   bool
+	strict        bool
+	errorOnCycle  bool
+	strictLength  bool
+	merge         bool
+	// checkedNumbers and runeStrings are the numeric conversion modes
+	checkedNumbers bool
+	runeStrings    bool
+	slicePolicy    SlicePolicy
+	// matchers match the field names when no field has the exact name, matcherKey identifies them
+	matchers   []*NameMatcher
+	matcherKey string
+	timeFormat string
+	// timeLayouts are the layouts to parse a string to time.Time in order, timeFormat if it is empty
+	timeLayouts []string
+	lenientTime bool
+	epochUnit   EpochUnit
+	// location normalizes the copied times if it is not nil
+	location       *time.Location
+	stripMonotonic bool
+	// maxDepth is unlimited if it is negative
+	maxDepth   int
+	converters map[typePair]converter
+	// concreteType picks the concrete type for an interface
+	concreteType func(src, dst reflect.Type) reflect.Type
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		timeFormat: time.RFC3339Nano,
+		maxDepth:   -1,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// CollectErrors copies all the fields and returns every field error joined by errors.Join,
+// instead of stopping at the first error
+func CollectErrors() Option {
+	return func(o *options) {
+		o.collectErrors = true
+	}
+}
+
+// IgnoreEmpty skips the source fields that have the zero value, so they do not overwrite the destination
+func IgnoreEmpty() Option {
+	return func(o *options) {
+		o.ignoreEmpty = true
+	}
+}
+
+// Strict returns an error for the exported destination fields that no source field is copied to
+func Strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// CaseInsensitive matches the field names ignoring case when no field has the exact name,
+// it is the same as WithNameMatcher(MatchCaseInsensitive)
+func CaseInsensitive() Option {
+	return WithNameMatcher(MatchCaseInsensitive)
+}
+
+// WithNameMatcher matches the field names and the map keys with the matchers in order when no field has the exact name.
+// The matchers should be package-level values, see NewNameMatcher.
+func WithNameMatcher(matchers ...*NameMatcher) Option {
+	return func(o *options) {
+		o.matchers = append(o.matchers, matchers...)
+		o.matcherKey = matcherKey(o.matchers)
+	}
+}
+
+// ErrorOnCycle returns an error when a pointer refers back to a value that is being copied,
+// instead of copying the cycle
+func ErrorOnCycle() Option {
+	return func(o *options) {
+		o.errorOnCycle = true
+	}
+}
+
+// Merge copies only the source fields that are not blank onto the existing destination, IsBlank decides what is blank.
+// The nested structs, pointers and maps are merged recursively, and the slices follow the policy.
+func Merge(policy SlicePolicy) Option {
+	return func(o *options) {
+		o.merge = true
+		o.slicePolicy = policy
+	}
+}
+
+// StrictLength returns an error when the length of the source does not match the length of the destination array,
+// instead of truncating the elements or leaving them zero
+func StrictLength() Option {
+	return func(o *options) {
+		o.strictLength = true
+	}
+}
+
+// CheckedNumbers returns an error when a number does not fit the destination numeric type,
+// e.g. 300 to uint8 or 1.5 to int, instead of truncating it like Go conversions
+func CheckedNumbers() Option {
+	return func(o *options) {
+		o.checkedNumbers = true
+	}
+}
+
+// RuneStrings converts the integers to the strings as the runes like string(rune(i)) the way Go conversions do,
+// instead of formatting them as the decimal numbers like strconv.Itoa. The text methods like time.Duration.String take priority.
+func RuneStrings() Option {
+	return func(o *options) {
+		o.runeStrings = true
+	}
+}
+
+// WithTimeFormat sets the layout to copy between time.Time and string, the default is time.RFC3339Nano
+func WithTimeFormat(layout string) Option {
+	return func(o *options) {
+		o.timeFormat = layout
+	}
+}
+
+// WithTimeLayouts sets the layouts to parse a string to time.Time, they are tried in order.
+// TimeUnix parses the Unix seconds. The strings are formatted with WithTimeFormat.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(o *options) {
+		o.timeLayouts = layouts
+	}
+}
+
+// LenientTime does not copy a string that cannot be parsed to time.Time, the destination keeps its value
+// like it does for an empty string, instead of returning an error
+func LenientTime() Option {
+	return func(o *options) {
+		o.lenientTime = true
+	}
+}
+
+// WithEpochUnit sets the unit of the int, uint and float numbers copied from and to time.Time, the default is UnixNano.
+// The options of the copier tag like copier:",unixmilli" set the unit of the field.
+func WithEpochUnit(unit EpochUnit) Option {
+	return func(o *options) {
+		o.epochUnit = unit
+	}
+}
+
+// InLocation sets the location of the copied times, the results do not depend on the local time zone
+func InLocation(loc *time.Location) Option {
+	return func(o *options) {
+		o.location = loc
+	}
+}
+
+// InUTC sets UTC to the copied times
+func InUTC() Option {
+	return InLocation(time.UTC)
+}
+
+// StripMonotonic strips the monotonic clock readings from the copied times
+func StripMonotonic() Option {
+	return func(o *options) {
+		o.stripMonotonic = true
+	}
+}
+
+// MaxDepth returns an error for the structs and maps nested deeper than n,
+// e.g. the depth of Orders[3].Items is 3
+func MaxDepth(n int) Option {
+	return func(o *options) {
+		o.maxDepth = n
+	}
+}
+
+// WithConcreteType sets the function that picks the concrete type to copy a value of the type src to,
+// when the destination is the interface type dst. It returns nil to copy to src itself,
+// e.g. when dst is an interface of another package that src does not implement.
+func WithConcreteType(f func(src, dst reflect.Type) reflect.Type) Option {
+	return func(o *options) {
+		o.concreteType = f
+	}
+}
+
+// WithConverter copies S to D with the function, it takes priority over the registered converters and the built-in conversions.
+// The conversions between *S, S, *D and D are derived from it like RegisterConverter.
+func WithConverter[S, D any](f func(S) (D, error)) Option {
+	key, vf := newValueFunc(f)
+	return func(o *options) {
+		if o.converters == nil {
+			o.converters = map[typePair]converter{}
+		}
+		addConverter(o.converters, key, vf)
+	}
+}
@@ -0,0 +1,449 @@
+package xgo
+
+import (
+	"cmp"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/glassonion1/xgo/internal/tag"
+)
+
+// copyPlan is a precompiled field mapping from a source struct type to a destination struct type
+type copyPlan struct {
+	fields []fieldPlan
+	// unmatched are the exported destination fields that no source field is copied to
+	unmatched []reflect.StructField
+	// unmapped are the exported source fields that are not copied
+	unmapped []reflect.StructField
+	// missing are the unmatched destination fields that the copier tag requires
+	missing []reflect.StructField
+}
+
+// fieldPlan describes how a single source field is copied to a destination field
+type fieldPlan struct {
+	// name is the source field name or path, used in error messages
+	name string
+	// dstName is the destination field name or path
+	dstName  string
+	srcIndex []int
+	dstIndex []int
+	// dstIndirect reports whether the destination field goes through a pointer
+	dstIndirect bool
+	// byTag reports whether the fields are matched by the copier tag
+	byTag bool
+	// the options of the copier tags
+	omitEmpty bool
+	required  bool
+	shallow   bool
+	// epoch is the epoch unit option, it is empty for the unit of the options
+	epoch string
+	// convert is the conversion chosen for the field pair, nil if there is none
+	convert convertFunc
+}
+
+// convertFunc converts src and sets it to dst, it reports whether the value was handled
+type convertFunc func(src, dst reflect.Value) (bool, error)
+
+type typePair struct {
+	src, dst reflect.Type
+}
+
+// planKey is the key of planCache, the plan depends on the name matchers and the registered converters
+type planKey struct {
+	typePair
+	matchers string
+	gen      uint64
+}
+
+// convertKey is the key of convertCache, the conversion depends on the registered converters
+type convertKey struct {
+	typePair
+	gen uint64
+}
+
+var (
+	// planCache caches *copyPlan by planKey
+	planCache sync.Map
+	// convertCache caches convertFunc by convertKey
+	convertCache sync.Map
+)
+
+// getPlan returns the cached copy plan for the type pair, compiling it on the first call
+func getPlan(src, dst reflect.Type, o *options) *copyPlan {
+	key := planKey{typePair{src, dst}, o.matcherKey, registryGen.Load()}
+	if p, ok := planCache.Load(key); ok {
+		return p.(*copyPlan)
+	}
+	p, _ := planCache.LoadOrStore(key, compilePlan(src, dst, o))
+	return p.(*copyPlan)
+}
+
+// compilePlan resolves the field mapping. Embedded structs are flattened,
+// so the promoted fields are matched the way Go promotes them.
+func compilePlan(src, dst reflect.Type, o *options) *copyPlan {
+	// What to do if the deepcopy destination model has a tag
+	var srcToDstTagMap = map[string]string{}
+	var dstTags = map[string]tag.Tag{}
+	// the destination fields that the tag maps from the nested source fields like Address.City,
+	// they are copied after the other fields
+	var paths []fieldPlan
+	// pathRoots are the source fields that the paths go through
+	var pathRoots = map[string]bool{}
+	for _, dstF := range reflect.VisibleFields(dst) {
+		t := fieldTag(dstF, src)
+		dstTags[dstF.Name] = t
+		if t.Name == "" || t.Skip {
+			continue
+		}
+		if !isPath(t.Name) {
+			srcToDstTagMap[t.Name] = dstF.Name
+			continue
+		}
+		srcField, ok := fieldByPath(src, t.Name)
+		if !ok || !dstF.IsExported() {
+			continue
+		}
+		pathRoots[src.FieldByIndex(srcField.Index[:1]).Name] = true
+		paths = append(paths, fieldPlan{
+			name:        t.Name,
+			dstName:     dstF.Name,
+			srcIndex:    srcField.Index,
+			dstIndex:    dstF.Index,
+			dstIndirect: isIndirectPath(dst, dstF.Index),
+			byTag:       true,
+			omitEmpty:   t.OmitEmpty,
+			required:    t.Required,
+			shallow:     t.Shallow,
+			epoch:       t.Epoch,
+			convert:     planConvert(srcField.Type, dstF.Type, t.Deep),
+		})
+	}
+
+	plan := &copyPlan{}
+	for _, field := range reflect.VisibleFields(src) {
+		if !field.IsExported() {
+			continue
+		}
+
+		srcTag := fieldTag(field, dst)
+		if srcTag.Skip {
+			continue
+		}
+		dstFieldName := field.Name
+		byTag := false
+		if srcTag.Name != "" {
+			dstFieldName, byTag = srcTag.Name, true
+		}
+		if name, ok := srcToDstTagMap[field.Name]; ok {
+			dstFieldName, byTag = name, true
+		}
+
+		var dstField reflect.StructField
+		var ok bool
+		if isPath(dstFieldName) {
+			dstField, ok = fieldByPath(dst, dstFieldName)
+		} else {
+			dstField, ok = dst.FieldByName(dstFieldName)
+		}
+		if !ok && !isPath(dstFieldName) {
+			dstField, ok = fieldByMatchers(dst, dstFieldName, o.matchers)
+		}
+		// the promoted fields of the embedded struct are copied instead,
+		// unless the destination has a named field for it
+		if isEmbeddedStruct(field) && (!ok || dstField.Anonymous) {
+			continue
+		}
+		// Ignores private field
+		if !ok || !IsFirstUpper(dstFieldName) || !dstField.IsExported() {
+			if !pathRoots[field.Name] {
+				plan.unmapped = append(plan.unmapped, field)
+			}
+			continue
+		}
+		var dstTag tag.Tag
+		if !isPath(dstFieldName) {
+			dstTag = dstTags[dstField.Name]
+		}
+		if dstTag.Skip {
+			continue
+		}
+
+		dstName := dstField.Name
+		if isPath(dstFieldName) {
+			dstName = dstFieldName
+		}
+		plan.fields = append(plan.fields, fieldPlan{
+			name:        field.Name,
+			dstName:     dstName,
+			srcIndex:    field.Index,
+			dstIndex:    dstField.Index,
+			dstIndirect: isIndirectPath(dst, dstField.Index),
+			byTag:       byTag,
+			omitEmpty:   srcTag.OmitEmpty || dstTag.OmitEmpty,
+			required:    srcTag.Required || dstTag.Required,
+			shallow:     srcTag.Shallow || dstTag.Shallow,
+			epoch:       cmp.Or(srcTag.Epoch, dstTag.Epoch),
+			convert:     planConvert(field.Type, dstField.Type, srcTag.Deep || dstTag.Deep),
+		})
+	}
+	plan.fields = append(plan.fields, paths...)
+
+	matched := map[string]bool{}
+	for _, f := range plan.fields {
+		// the path to a nested field matches the outer field
+		name, _, _ := strings.Cut(f.dstName, ".")
+		matched[name] = true
+	}
+	for _, f := range reflect.VisibleFields(dst) {
+		if f.IsExported() && !isEmbeddedStruct(f) && !matched[f.Name] && !dstTags[f.Name].Skip {
+			plan.unmatched = append(plan.unmatched, f)
+			if dstTags[f.Name].Required {
+				plan.missing = append(plan.missing, f)
+			}
+		}
+	}
+	return plan
+}
+
+// planConvert returns the conversion of the field pair, nil for the deep option of the copier tag
+func planConvert(src, dst reflect.Type, deep bool) convertFunc {
+	if deep && deepCopyable(src) && deepCopyable(dst) {
+		// copied by the nested copy unless there is the converter of RegisterConverter
+		if _, ok := lookupConverter(src, dst); !ok {
+			return nil
+		}
+	}
+	return getConvert(src, dst)
+}
+
+// isPath determines whether the name of the copier tag is a path to a nested field like Address.City
+func isPath(name string) bool {
+	return strings.Contains(name, ".")
+}
+
+// fieldByPath returns the exported nested field of the dotted path,
+// the index of the field goes through the structs and the pointers to structs
+func fieldByPath(t reflect.Type, name string) (reflect.StructField, bool) {
+	var (
+		f     reflect.StructField
+		index []int
+	)
+	for _, n := range strings.Split(name, ".") {
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return reflect.StructField{}, false
+		}
+		var ok bool
+		f, ok = t.FieldByName(n)
+		if !ok || !f.IsExported() {
+			return reflect.StructField{}, false
+		}
+		index = append(index, f.Index...)
+		t = f.Type
+	}
+	f.Index = index
+	return f, true
+}
+
+// fieldTag parses the copier tag of the field for the other struct type,
+// the package qualifier is the last element of the import path since reflect has no package name
+func fieldTag(f reflect.StructField, other reflect.Type) tag.Tag {
+	s, ok := f.Tag.Lookup(tagCopier)
+	if !ok {
+		return tag.Tag{}
+	}
+	if other == nil || other.Name() == "" {
+		return tag.Parse(s)
+	}
+	pkg := path.Base(other.PkgPath())
+	return tag.Parse(s, pkg+"."+other.Name(), other.Name(), pkg)
+}
+
+// deepCopyable determines whether the nested copy copies the type instead of assigning it
+func deepCopyable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	case reflect.Struct:
+		return mergeable(t)
+	}
+	return false
+}
+
+// isEmbeddedStruct determines whether the field is an embedded struct that has exported fields to promote
+func isEmbeddedStruct(f reflect.StructField) bool {
+	if !f.Anonymous {
+		return false
+	}
+	t := indirectType(f.Type)
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for _, vf := range reflect.VisibleFields(t) {
+		if vf.IsExported() && !vf.Anonymous {
+			return true
+		}
+	}
+	return false
+}
+
+// isIndirectPath determines whether the field of the index goes through a pointer
+func isIndirectPath(t reflect.Type, index []int) bool {
+	for _, x := range index[:len(index)-1] {
+		t = t.Field(x).Type
+		if t.Kind() == reflect.Ptr {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex returns the nested field of the index, allocating the nil pointers on the way
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// getConvert returns the cached conversion for the type pair, nil if the types cannot be converted
+func getConvert(src, dst reflect.Type) convertFunc {
+	key := convertKey{typePair{src, dst}, registryGen.Load()}
+	if f, ok := convertCache.Load(key); ok {
+		return f.(convertFunc)
+	}
+	f, _ := convertCache.LoadOrStore(key, compileConvert(src, dst))
+	return f.(convertFunc)
+}
+
+func compileConvert(src, dst reflect.Type) convertFunc {
+
+	// the converter of RegisterConverter
+	if conv, ok := lookupConverter(src, dst); ok {
+		return conv
+	}
+
+	// the source and destination types are the same, Set does not copy an addressable value unlike Convert
+	if src == dst {
+		return func(s, d reflect.Value) (bool, error) {
+			d.Set(s)
+			return true, nil
+		}
+	}
+
+	if convertible(src, dst) {
+		return func(s, d reflect.Value) (bool, error) {
+			d.Set(s.Convert(dst))
+			return true, nil
+		}
+	}
+
+	// from pointer type to non pointer type
+	if src.Kind() == reflect.Ptr {
+		var elemConvert convertFunc
+		switch {
+		case convertible(src.Elem(), dst):
+			elemConvert = func(s, d reflect.Value) (bool, error) {
+				d.Set(s.Elem().Convert(dst))
+				return true, nil
+			}
+		case dst.Kind() == reflect.Ptr && convertible(src.Elem(), dst.Elem()):
+			elemConvert = func(s, d reflect.Value) (bool, error) {
+				rv := reflect.New(dst.Elem())
+				rv.Elem().Set(s.Elem().Convert(dst.Elem()))
+				d.Set(rv)
+				return true, nil
+			}
+		}
+		return func(s, d reflect.Value) (bool, error) {
+			if s.IsNil() {
+				return true, nil
+			}
+			if elemConvert == nil {
+				return false, nil
+			}
+			return elemConvert(s, d)
+		}
+	}
+
+	// from non pointer type to pointer type
+	if dst.Kind() == reflect.Ptr && convertible(src, dst.Elem()) {
+		return func(s, d reflect.Value) (bool, error) {
+			rv := reflect.New(dst.Elem())
+			rv.Elem().Set(s.Convert(dst.Elem()))
+			d.Set(rv)
+			return true, nil
+		}
+	}
+
+	return nil
+}
+
+// convertible reports whether src is converted to dst with reflect.Value.Convert.
+// A slice is not converted to an array since Convert panics if the slice is shorter than the array.
+func convertible(src, dst reflect.Type) bool {
+	if src.Kind() == reflect.Slice && indirectType(dst).Kind() == reflect.Array {
+		return false
+	}
+	// an integer is formatted to a decimal string by convertText instead of the rune
+	if isInteger(src.Kind()) && dst.Kind() == reflect.String {
+		return false
+	}
+	return src.ConvertibleTo(dst)
+}
+
+// keyField is an exported struct field and its key name in a map
+type keyField struct {
+	name      string
+	key       string
+	index     []int
+	omitEmpty bool
+	required  bool
+}
+
+// keyFieldCache caches []keyField by struct type
+var keyFieldCache sync.Map
+
+// getKeyFields returns the exported fields of the struct type, the copier tag renames the key.
+// Embedded structs are flattened.
+func getKeyFields(t reflect.Type) []keyField {
+	if f, ok := keyFieldCache.Load(t); ok {
+		return f.([]keyField)
+	}
+
+	var fields []keyField
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || isEmbeddedStruct(field) {
+			continue
+		}
+		t := fieldTag(field, nil)
+		if t.Skip {
+			continue
+		}
+		key := field.Name
+		if t.Name != "" {
+			key = t.Name
+		}
+		fields = append(fields, keyField{
+			name:      field.Name,
+			key:       key,
+			index:     field.Index,
+			omitEmpty: t.OmitEmpty,
+			required:  t.Required,
+		})
+	}
+
+	f, _ := keyFieldCache.LoadOrStore(t, fields)
+	return f.([]keyField)
+}
@@ -0,0 +1,140 @@
+package xgo
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// converter is a conversion for a type pair, derived reports whether it is a pointer variant of a registered conversion
+type converter struct {
+	convert convertFunc
+	derived bool
+}
+
+// valueFunc converts a source value to a destination value
+type valueFunc func(src reflect.Value) (reflect.Value, error)
+
+var (
+	registryMu sync.RWMutex
+	// registry holds the converters of RegisterConverter by typePair
+	registry = map[typePair]converter{}
+	// registryGen is the generation of the registry in the cache keys, a registration makes the compiled conversions stale
+	registryGen atomic.Uint64
+)
+
+// RegisterConverter registers the function that DeepCopy uses to copy S to D.
+// The conversions between *S, S, *D and D are derived from it unless they are registered.
+// A registered converter takes priority over the built-in conversions.
+func RegisterConverter[S, D any](f func(S) (D, error)) {
+	key, vf := newValueFunc(f)
+
+	registryMu.Lock()
+	addConverter(registry, key, vf)
+	// the conversions compiled before this are stored with the previous generation even when they are stored later
+	gen := registryGen.Add(1)
+	registryMu.Unlock()
+
+	// the stale conversions are never loaded again, they are removed to free them
+	planCache.Range(func(k, _ any) bool {
+		if k.(planKey).gen < gen {
+			planCache.Delete(k)
+		}
+		return true
+	})
+	convertCache.Range(func(k, _ any) bool {
+		if k.(convertKey).gen < gen {
+			convertCache.Delete(k)
+		}
+		return true
+	})
+}
+
+// lookupConverter returns the registered conversion for the type pair
+func lookupConverter(src, dst reflect.Type) (convertFunc, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	c, ok := registry[typePair{src, dst}]
+	return c.convert, ok
+}
+
+func newValueFunc[S, D any](f func(S) (D, error)) (typePair, valueFunc) {
+	key := typePair{
+		src: reflect.TypeOf((*S)(nil)).Elem(),
+		dst: reflect.TypeOf((*D)(nil)).Elem(),
+	}
+	return key, func(src reflect.Value) (reflect.Value, error) {
+		// a nil interface is passed as the zero value
+		s, _ := src.Interface().(S)
+		d, err := f(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&d).Elem(), nil
+	}
+}
+
+// addConverter adds the conversion and its pointer variants to m, a derived variant does not overwrite a registered one
+func addConverter(m map[typePair]converter, key typePair, f valueFunc) {
+	m[key] = converter{convert: func(s, d reflect.Value) (bool, error) {
+		v, err := f(s)
+		if err != nil {
+			return false, err
+		}
+		d.Set(v)
+		return true, nil
+	}}
+
+	derive := func(k typePair, conv convertFunc) {
+		if c, ok := m[k]; ok && !c.derived {
+			return
+		}
+		m[k] = converter{convert: conv, derived: true}
+	}
+
+	src, dst := key.src, key.dst
+	if src.Kind() != reflect.Ptr {
+		// *S -> D, a nil pointer is not copied
+		derive(typePair{reflect.PointerTo(src), dst}, func(s, d reflect.Value) (bool, error) {
+			if s.IsNil() {
+				return true, nil
+			}
+			v, err := f(s.Elem())
+			if err != nil {
+				return false, err
+			}
+			d.Set(v)
+			return true, nil
+		})
+	}
+	if dst.Kind() == reflect.Ptr {
+		return
+	}
+	// S -> *D
+	derive(typePair{src, reflect.PointerTo(dst)}, func(s, d reflect.Value) (bool, error) {
+		v, err := f(s)
+		if err != nil {
+			return false, err
+		}
+		rv := reflect.New(dst)
+		rv.Elem().Set(v)
+		d.Set(rv)
+		return true, nil
+	})
+	if src.Kind() != reflect.Ptr {
+		// *S -> *D
+		derive(typePair{reflect.PointerTo(src), reflect.PointerTo(dst)}, func(s, d reflect.Value) (bool, error) {
+			if s.IsNil() {
+				return true, nil
+			}
+			v, err := f(s.Elem())
+			if err != nil {
+				return false, err
+			}
+			rv := reflect.New(dst)
+			rv.Elem().Set(v)
+			d.Set(rv)
+			return true, nil
+		})
+	}
+}
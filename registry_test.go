@@ -0,0 +1,218 @@
+package xgo_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo"
+)
+
+func TestRegisterConverter(t *testing.T) {
+	type Money struct {
+		Amount   int64
+		Currency string
+	}
+	type ULID [4]byte
+	type Celsius float64
+	type Fahrenheit float64
+	type Label struct {
+		Text string
+	}
+
+	xgo.RegisterConverter(func(m Money) (string, error) {
+		if m.Currency == "" {
+			return "", errors.New("currency is empty")
+		}
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency), nil
+	})
+	xgo.RegisterConverter(func(id ULID) (string, error) {
+		return fmt.Sprintf("%x", id[:]), nil
+	})
+	// the registered converter takes priority over the conversion of the underlying types
+	xgo.RegisterConverter(func(c Celsius) (Fahrenheit, error) {
+		return Fahrenheit(c*9/5 + 32), nil
+	})
+	// the registered converter takes priority over the derived one
+	xgo.RegisterConverter(func(l Label) (string, error) {
+		return l.Text, nil
+	})
+	xgo.RegisterConverter(func(l *Label) (string, error) {
+		if l == nil {
+			return "", nil
+		}
+		return strings.ToUpper(l.Text), nil
+	})
+
+	type Product struct {
+		ID          ULID
+		Price       Money
+		Sale        *Money
+		List        Money
+		Discount    *Money
+		Temperature Celsius
+		Label       *Label
+		Prices      []Money
+	}
+	type ProductDTO struct {
+		ID          string
+		Price       string
+		Sale        string
+		List        *string
+		Discount    *string
+		Temperature Fahrenheit
+		Label       string
+		Prices      []string
+	}
+
+	jpy := Money{Amount: 100, Currency: "JPY"}
+
+	tests := []struct {
+		name string
+		src  interface{}
+		opts []xgo.Option
+		want interface{}
+		err  bool
+	}{
+		{
+			name: "registered converters",
+			src: Product{
+				ID:          ULID{0x01, 0x02, 0x03, 0x04},
+				Price:       jpy,
+				Sale:        &jpy,
+				List:        jpy,
+				Discount:    &jpy,
+				Temperature: 100,
+				Label:       &Label{Text: "new"},
+				Prices:      []Money{jpy, jpy},
+			},
+			want: &ProductDTO{
+				ID:          "01020304",
+				Price:       "100 JPY",
+				Sale:        "100 JPY",
+				List:        xgo.ToPtr("100 JPY"),
+				Discount:    xgo.ToPtr("100 JPY"),
+				Temperature: 212,
+				Label:       "NEW",
+				Prices:      []string{"100 JPY", "100 JPY"},
+			},
+		},
+		{
+			name: "nil pointer",
+			src:  Product{Price: jpy, List: jpy},
+			want: &ProductDTO{
+				ID:          "00000000",
+				Price:       "100 JPY",
+				List:        xgo.ToPtr("100 JPY"),
+				Temperature: 32,
+			},
+		},
+		{
+			name: "per copy converter takes priority",
+			src:  Product{Price: jpy, List: jpy},
+			opts: []xgo.Option{xgo.WithConverter(func(m Money) (string, error) {
+				return m.Currency, nil
+			})},
+			want: &ProductDTO{
+				ID:          "00000000",
+				Price:       "JPY",
+				List:        xgo.ToPtr("JPY"),
+				Temperature: 32,
+			},
+		},
+		{
+			name: "converter error",
+			src:  Product{},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := &ProductDTO{}
+			err := xgo.DeepCopy(tt.src, got, tt.opts...)
+			if tt.err {
+				var copyErr *xgo.CopyError
+				if !errors.As(err, &copyErr) {
+					t.Errorf("testing %s: should be CopyError for %#v but: %v", tt.name, tt.src, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.src, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+
+	t.Run("priority over the time conversion", func(t *testing.T) {
+		type Event struct {
+			At time.Time
+		}
+		type EventDTO struct {
+			At string
+		}
+		at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		got := &EventDTO{}
+		err := xgo.DeepCopy(Event{At: at}, got, xgo.WithConverter(func(t time.Time) (string, error) {
+			return t.Format(time.Kitchen), nil
+		}))
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(&EventDTO{At: "12:00AM"}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("top-level values", func(t *testing.T) {
+		type Point struct {
+			X int
+		}
+		type PointDTO struct {
+			X int
+		}
+		xgo.RegisterConverter(func(p Point) (PointDTO, error) {
+			return PointDTO{X: p.X * 10}, nil
+		})
+
+		got := PointDTO{}
+		if err := xgo.DeepCopy(Point{X: 1}, &got); err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(PointDTO{X: 10}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+
+		copier, err := xgo.NewCopier[Point, PointDTO]()
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		got, err = copier.Copy(Point{X: 2})
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(PointDTO{X: 20}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+
+		var s string
+		err = xgo.DeepCopy(jpy, &s, xgo.WithConverter(func(m Money) (string, error) {
+			return m.Currency, nil
+		}))
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff("JPY", s); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package xgo
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// isTextPair reports whether convertText copies src to dst
+func isTextPair(src, dst reflect.Type) bool {
+	s, d := indirectType(src), indirectType(dst)
+	switch {
+	case s == timeType || d == timeType:
+		// setTimeField copies time.Time with the time format
+		return false
+	case s.Kind() == reflect.String && d.Kind() == reflect.String:
+		return false
+	case d.Kind() == reflect.String:
+		// fmt.Stringer formats only the bools and the numbers like enums, the String of a struct like a protobuf message is for debugging
+		return hasTextMethod(s, textMarshalerType) || s.Kind() == reflect.Bool || isNumber(s.Kind())
+	case s.Kind() == reflect.String:
+		return hasTextMethod(d, textUnmarshalerType) || d.Kind() == reflect.Bool || isNumber(d.Kind())
+	}
+	return false
+}
+
+// hasTextMethod determines whether the type or the pointer to it implements one of the interfaces
+func hasTextMethod(t reflect.Type, ifaces ...reflect.Type) bool {
+	for _, iface := range ifaces {
+		if reflect.PointerTo(t).Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertText parses a string to a number, a bool, time.Duration or an encoding.TextUnmarshaler,
+// and formats them to a string with encoding.TextMarshaler, or fmt.Stringer for the bools and the numbers. It reports whether the value was handled.
+func (c *deepCopier) convertText(src, dst reflect.Value) (bool, error) {
+	if !src.CanInterface() || !isTextPair(src.Type(), dst.Type()) {
+		return false, nil
+	}
+	err := setIndirect(src, dst, func(src, dst reflect.Value) error {
+		if src.Kind() == reflect.String {
+			return parseText(src.String(), dst)
+		}
+		s, err := formatText(src)
+		if err != nil {
+			return err
+		}
+		dst.SetString(s)
+		return nil
+	})
+	if err != nil {
+		return true, c.fail(src.Type(), dst.Type(), err)
+	}
+	return true, nil
+}
+
+// parseText parses the string to the value
+func parseText(s string, v reflect.Value) error {
+	p := reflect.New(v.Type())
+	if u, ok := p.Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+		v.Set(p.Elem())
+		return nil
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+		return nil
+	}
+	return setNumber(reflect.ValueOf(s), v, false)
+}
+
+// formatText formats the value to a string, encoding.TextMarshaler takes priority over fmt.Stringer
+func formatText(v reflect.Value) (string, error) {
+	// the pointer has the methods of both receivers
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	switch x := p.Interface().(type) {
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		return string(b), err
+	case fmt.Stringer:
+		return x.String(), nil
+	}
+
+	switch {
+	case v.Kind() == reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case v.CanInt():
+		return strconv.FormatInt(v.Int(), 10), nil
+	case v.CanUint():
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+}
+
+// setIndirect sets src to dst with the function, dereferencing the source pointer and allocating the destination pointer.
+// A nil pointer and an empty string are not copied.
+func setIndirect(src, dst reflect.Value, set func(src, dst reflect.Value) error) error {
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			return nil
+		}
+		src = src.Elem()
+	}
+	if src.Kind() == reflect.String && src.Len() == 0 {
+		return nil
+	}
+
+	v := dst
+	if dst.Kind() == reflect.Ptr {
+		v = reflect.New(dst.Type().Elem()).Elem()
+	}
+	if err := set(src, v); err != nil {
+		return err
+	}
+	if dst.Kind() == reflect.Ptr {
+		dst.Set(v.Addr())
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package xgo_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo"
+)
+
+// textID is a uuid-like type that has the text methods
+type textID [4]byte
+
+func (id textID) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(id[:])), nil
+}
+
+func (id *textID) UnmarshalText(b []byte) error {
+	if hex.DecodedLen(len(b)) != len(id) {
+		return errors.New("invalid id")
+	}
+	_, err := hex.Decode(id[:], b)
+	return err
+}
+
+type textStatus int
+
+func (s textStatus) String() string {
+	switch s {
+	case 1:
+		return "active"
+	}
+	return "unknown"
+}
+
+// textMessage is a struct like a protobuf message that has String for debugging
+type textMessage struct {
+	Seconds int64
+}
+
+func (m *textMessage) String() string {
+	return "seconds:1"
+}
+
+func TestDeepCopy_text(t *testing.T) {
+	type Query struct {
+		ID      string
+		Page    string
+		Active  string
+		Score   string
+		Timeout string
+		Limit   *string
+	}
+	type Model struct {
+		ID      textID
+		Page    int
+		Active  bool
+		Score   float64
+		Timeout time.Duration
+		Limit   *uint8
+	}
+	type Status struct {
+		Status textStatus
+	}
+	type StatusDTO struct {
+		Status string
+	}
+
+	tests := []struct {
+		name string
+		src  interface{}
+		dst  interface{}
+		opts []xgo.Option
+		want interface{}
+	}{
+		{
+			name: "parse",
+			src: Query{
+				ID:      "0a0b0c0d",
+				Page:    "3",
+				Active:  "true",
+				Score:   "1.5",
+				Timeout: "1m30s",
+				Limit:   xgo.ToPtr("10"),
+			},
+			dst: &Model{},
+			want: &Model{
+				ID:      textID{0x0a, 0x0b, 0x0c, 0x0d},
+				Page:    3,
+				Active:  true,
+				Score:   1.5,
+				Timeout: 90 * time.Second,
+				Limit:   xgo.ToPtr(uint8(10)),
+			},
+		},
+		{
+			name: "empty strings are not parsed",
+			src:  Query{},
+			dst:  &Model{Page: 1},
+			want: &Model{Page: 1},
+		},
+		{
+			name: "format",
+			src: Model{
+				ID:      textID{0x0a, 0x0b, 0x0c, 0x0d},
+				Page:    3,
+				Active:  true,
+				Score:   1.5,
+				Timeout: 90 * time.Second,
+			},
+			dst: &Query{},
+			want: &Query{
+				ID:      "0a0b0c0d",
+				Page:    "3",
+				Active:  "true",
+				Score:   "1.5",
+				Timeout: "1m30s",
+			},
+		},
+		{
+			name: "stringer",
+			src:  Status{Status: 1},
+			dst:  &StatusDTO{},
+			want: &StatusDTO{Status: "active"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.src, tt.dst, tt.opts...)
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.src, err)
+			}
+			if diff := cmp.Diff(tt.want, tt.dst); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+
+	invalid := []struct {
+		name string
+		src  Query
+	}{
+		{name: "invalid text", src: Query{ID: "xxxx"}},
+		{name: "invalid int", src: Query{Page: "R2D2"}},
+		{name: "invalid bool", src: Query{Active: "yes"}},
+		{name: "invalid float", src: Query{Score: "1,5"}},
+		{name: "invalid duration", src: Query{Timeout: "90"}},
+		{name: "overflow", src: Query{Limit: xgo.ToPtr("300")}},
+	}
+	for _, tt := range invalid {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgo.DeepCopy(tt.src, &Model{})
+			var copyErr *xgo.CopyError
+			if !errors.As(err, &copyErr) {
+				t.Errorf("testing %s: should be CopyError but: %v", tt.name, err)
+			}
+		})
+	}
+
+	t.Run("struct is not stringified", func(t *testing.T) {
+		t.Parallel()
+		type Src struct {
+			Message *textMessage
+		}
+		type Dst struct {
+			Message string
+		}
+		err := xgo.DeepCopy(Src{Message: &textMessage{Seconds: 1}}, &Dst{})
+		var copyErr *xgo.CopyError
+		if !errors.As(err, &copyErr) {
+			t.Errorf("should be CopyError but: %v", err)
+		}
+	})
+}
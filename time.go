@@ -0,0 +1,260 @@
+package xgo
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// EpochUnit is the unit of the numbers copied from and to time.Time, the default is UnixNano
+type EpochUnit int
+
+const (
+	// UnixNano is the nanoseconds since the Unix epoch like time.Time.UnixNano
+	UnixNano EpochUnit = iota
+	// UnixSeconds is the seconds since the Unix epoch like time.Time.Unix
+	UnixSeconds
+	// UnixMilli is the milliseconds since the Unix epoch like time.Time.UnixMilli
+	UnixMilli
+	// UnixMicro is the microseconds since the Unix epoch like time.Time.UnixMicro
+	UnixMicro
+)
+
+// epochUnits are the epoch units of the copier tag options
+var epochUnits = map[string]EpochUnit{
+	"unix":      UnixSeconds,
+	"unixmilli": UnixMilli,
+	"unixmicro": UnixMicro,
+	"unixnano":  UnixNano,
+}
+
+// nanos returns the nanoseconds of the unit
+func (u EpochUnit) nanos() int64 {
+	switch u {
+	case UnixSeconds:
+		return int64(time.Second)
+	case UnixMilli:
+		return int64(time.Millisecond)
+	case UnixMicro:
+		return int64(time.Microsecond)
+	}
+	return 1
+}
+
+// fromTime returns the number of the unit since the Unix epoch, a float has the fraction
+func (u EpochUnit) fromTime(t time.Time, float bool) reflect.Value {
+	if float {
+		n := float64(u.nanos())
+		return reflect.ValueOf(float64(t.Unix())*float64(time.Second)/n + float64(t.Nanosecond())/n)
+	}
+	switch u {
+	case UnixSeconds:
+		return reflect.ValueOf(t.Unix())
+	case UnixMilli:
+		return reflect.ValueOf(t.UnixMilli())
+	case UnixMicro:
+		return reflect.ValueOf(t.UnixMicro())
+	}
+	return reflect.ValueOf(t.UnixNano())
+}
+
+// toTime returns the time of the number of the unit since the Unix epoch
+func (u EpochUnit) toTime(v reflect.Value) time.Time {
+	var n int64
+	switch {
+	case v.CanInt():
+		n = v.Int()
+	case v.CanUint():
+		n = int64(v.Uint())
+	default:
+		sec, frac := math.Modf(v.Float() * float64(u.nanos()) / float64(time.Second))
+		return time.Unix(int64(sec), int64(math.Round(frac*float64(time.Second))))
+	}
+	switch u {
+	case UnixSeconds:
+		return time.Unix(n, 0)
+	case UnixMilli:
+		return time.UnixMilli(n)
+	case UnixMicro:
+		return time.UnixMicro(n)
+	}
+	return time.Unix(0, n)
+}
+
+// withEpochUnit sets the epoch unit of the copier tag to the options, the returned function restores them
+func (c *deepCopier) withEpochUnit(unit EpochUnit) func() {
+	opts := c.opts
+	o := *opts
+	o.epochUnit = unit
+	c.opts = &o
+	return func() {
+		c.opts = opts
+	}
+}
+
+// setEpochNumber sets the time to the number of the epoch unit,
+// it returns an error for the epoch that does not fit the integer type
+func (o *options) setEpochNumber(t time.Time, dst reflect.Value) error {
+	if dst.Kind() == reflect.Float32 || dst.Kind() == reflect.Float64 {
+		// a float has the range of the epoch, not the precision
+		dst.SetFloat(o.epochUnit.fromTime(t, true).Float())
+		return nil
+	}
+	// the epoch is checked since an integer smaller than int64 wraps it
+	return setNumber(o.epochUnit.fromTime(t, false), dst, true)
+}
+
+// setEpoch copies an epoch number through time.Time with the custom setter, e.g. int64 to *timestamppb.Timestamp
+// when the setter copies time.Time to *timestamppb.Timestamp, and the other way around.
+// The number is the epoch unit of the options. It reports whether the value was handled.
+func (c *deepCopier) setEpoch(src, dst reflect.Value) (bool, error) {
+	switch {
+	case isEpoch(src.Type()) && isTimeLike(dst.Type()):
+		t := c.opts.normalizeTime(c.opts.epochUnit.toTime(src))
+		return c.setter(reflect.ValueOf(t), dst)
+	case isEpoch(dst.Type()) && isTimeLike(src.Type()):
+		v := reflect.New(timeType).Elem()
+		isSet, err := c.setter(src, v)
+		if err != nil || !isSet {
+			return isSet, err
+		}
+		// the zero time leaves the number as it is
+		if v.IsZero() {
+			return true, nil
+		}
+		return true, c.opts.setEpochNumber(c.opts.normalizeTime(v.Interface().(time.Time)), dst)
+	}
+	return false, nil
+}
+
+// isTimeLike determines whether the type may be a time that the custom setter copies from and to time.Time
+func isTimeLike(t reflect.Type) bool {
+	return (t.Kind() == reflect.Ptr || t.Kind() == reflect.Struct) && !isTimeType(t)
+}
+
+// isTimeType determines whether the type is time.Time or *time.Time
+func isTimeType(t reflect.Type) bool {
+	return indirectType(t) == timeType
+}
+
+// isTimePair reports whether setTimeField can copy src to dst
+func isTimePair(src, dst reflect.Type) bool {
+	s, d := indirectType(src), indirectType(dst)
+	switch {
+	case s == timeType:
+		return isEpoch(d) || d.Kind() == reflect.String
+	case d == timeType:
+		return isEpoch(s) || s.Kind() == reflect.String
+	}
+	return false
+}
+
+// isEpoch determines whether the type is a number that setTimeField copies from and to time.Time,
+// time.Duration is not a time
+func isEpoch(t reflect.Type) bool {
+	return isNumber(t.Kind()) && t != durationType
+}
+
+// setTimeField copies time.Time to the number of the epoch unit and the string of the time format, and the other way around.
+// It returns an error for the epoch that does not fit the integer type.
+func setTimeField(src, dst reflect.Value, o *options) (bool, error) {
+	if !isTimePair(src.Type(), dst.Type()) {
+		return false, nil
+	}
+	return true, setIndirect(src, dst, func(src, dst reflect.Value) error {
+		if src.Type() == timeType {
+			t := o.normalizeTime(src.Interface().(time.Time))
+			if dst.Kind() == reflect.String {
+				dst.SetString(t.Format(o.timeFormat))
+				return nil
+			}
+			return o.setEpochNumber(t, dst)
+		}
+
+		if src.Kind() != reflect.String {
+			dst.Set(reflect.ValueOf(o.normalizeTime(o.epochUnit.toTime(src))))
+			return nil
+		}
+		t, ok, err := o.parseTime(src.String())
+		if ok {
+			dst.Set(reflect.ValueOf(o.normalizeTime(t)))
+		}
+		return err
+	})
+}
+
+// normalizesTime determines whether the options InLocation and StripMonotonic change the copied times
+func (o *options) normalizesTime() bool {
+	return o.location != nil || o.stripMonotonic
+}
+
+// normalizeTime sets the location of the options to the time and strips the monotonic clock reading,
+// the zero time stays zero
+func (o *options) normalizeTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	if o.location != nil {
+		t = t.In(o.location)
+	}
+	if o.stripMonotonic {
+		t = t.Round(0)
+	}
+	return t
+}
+
+// convertTime copies time.Time to time.Time with the options InLocation and StripMonotonic,
+// it reports whether the value was handled
+func (c *deepCopier) convertTime(src, dst reflect.Value) (bool, error) {
+	if !c.opts.normalizesTime() || !src.CanInterface() || !isTimeType(src.Type()) || !isTimeType(dst.Type()) {
+		return false, nil
+	}
+	return true, setIndirect(src, dst, func(src, dst reflect.Value) error {
+		dst.Set(reflect.ValueOf(c.opts.normalizeTime(src.Interface().(time.Time))))
+		return nil
+	})
+}
+
+// TimeUnix is the time layout of WithTimeLayouts for the Unix seconds in a string like "1748736000"
+const TimeUnix = "unix"
+
+// parseTime parses the string with the time layouts in order, it reports whether the time is parsed.
+// An empty string is not parsed, and LenientTime ignores the error so that the destination keeps its value.
+func (o *options) parseTime(s string) (time.Time, bool, error) {
+	if s == "" {
+		return time.Time{}, false, nil
+	}
+	layouts := o.timeLayouts
+	if len(layouts) == 0 {
+		layouts = []string{o.timeFormat}
+	}
+
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if layout == TimeUnix {
+			var sec int64
+			sec, err = strconv.ParseInt(s, 10, 64)
+			t = time.Unix(sec, 0)
+		} else {
+			t, err = time.Parse(layout, s)
+		}
+		if err == nil {
+			return t, true, nil
+		}
+	}
+	if o.lenientTime {
+		return time.Time{}, false, nil
+	}
+	if len(layouts) > 1 {
+		return time.Time{}, false, fmt.Errorf("%q does not match any of the time layouts %q", s, layouts)
+	}
+	return time.Time{}, false, err
+}
@@ -0,0 +1,214 @@
+package xgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Copier is a reusable, type-safe deep copier from S to D.
+// S and D are a struct, a pointer to a struct, a slice, an array or a map of them,
+// or a struct and a map that has string keys.
+type Copier[S, D any] struct {
+	setter SetCustomField
+	opts   []Option
+}
+
+// NewCopier creates a Copier, it returns an error if S cannot be copied to D
+func NewCopier[S, D any](opts ...Option) (*Copier[S, D], error) {
+	noop := func(src, dst reflect.Value) (bool, error) { return false, nil }
+	return newCopier[S, D](noop, true, opts)
+}
+
+// NewCopierWithCustomSetter creates a Copier that uses the custom setter.
+// Field pairs that are not handled by the built-in conversions are left to the setter,
+// so they are not reported at construction time.
+func NewCopierWithCustomSetter[S, D any](customSetter SetCustomField, opts ...Option) (*Copier[S, D], error) {
+	return newCopier[S, D](customSetter, false, opts)
+}
+
+func newCopier[S, D any](setter SetCustomField, strict bool, opts []Option) (*Copier[S, D], error) {
+	srcType := reflect.TypeOf((*S)(nil)).Elem()
+	dstType := reflect.TypeOf((*D)(nil)).Elem()
+
+	v := &mappingValidator{strict: strict, opts: newOptions(opts), visited: map[typePair]bool{}}
+	if err := v.validate(srcType, dstType); err != nil {
+		return nil, fmt.Errorf("cannot copy %v to %v: %w", srcType, dstType, err)
+	}
+	return &Copier[S, D]{setter: setter, opts: opts}, nil
+}
+
+// Copy copies src to a new D
+func (c *Copier[S, D]) Copy(src S) (D, error) {
+	var dst D
+	err := c.CopyInto(src, &dst)
+	return dst, err
+}
+
+// CopyInto copies src to dst
+func (c *Copier[S, D]) CopyInto(src S, dst *D) error {
+	if dst == nil {
+		return errors.New("copy to value is nil")
+	}
+
+	sv := reflect.ValueOf(&src).Elem()
+	if sv.Kind() == reflect.Ptr && sv.IsNil() {
+		return nil
+	}
+
+	dv := reflect.ValueOf(dst).Elem()
+	if dv.Kind() == reflect.Ptr {
+		if dv.IsNil() {
+			dv.Set(reflect.New(dv.Type().Elem()))
+		}
+		dv = dv.Elem()
+	}
+
+	return DeepCopyWithCustomSetter(sv.Interface(), dv.Addr().Interface(), c.setter, c.opts...)
+}
+
+// mappingValidator checks that a source type can be copied to a destination type
+type mappingValidator struct {
+	// strict reports field pairs that no built-in conversion can handle
+	strict  bool
+	opts    options
+	visited map[typePair]bool
+}
+
+func (v *mappingValidator) validate(src, dst reflect.Type) error {
+	src, dst = indirectType(src), indirectType(dst)
+
+	switch {
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		return v.validateStruct(src, dst)
+	case isList(src.Kind()) && isList(dst.Kind()):
+		return v.validateElem(src.Elem(), dst.Elem())
+	case src.Kind() == reflect.Map && dst.Kind() == reflect.Map:
+		return v.validateMap(src, dst)
+	case src.Kind() == reflect.Struct && isStringKeyMap(dst),
+		isStringKeyMap(src) && dst.Kind() == reflect.Struct:
+		// the keys are resolved at runtime
+		return nil
+	case !isCopyableKind(src.Kind()):
+		return fmt.Errorf("unsupported source kind %v", src.Kind())
+	case !isCopyableKind(dst.Kind()):
+		return fmt.Errorf("unsupported destination kind %v", dst.Kind())
+	}
+	return fmt.Errorf("cannot copy %v to %v", src.Kind(), dst.Kind())
+}
+
+func (v *mappingValidator) validateStruct(src, dst reflect.Type) error {
+	key := typePair{src, dst}
+	if v.visited[key] {
+		return nil
+	}
+	v.visited[key] = true
+
+	// the tags must refer to existing fields
+	for i := 0; i < src.NumField(); i++ {
+		f := src.Field(i)
+		if tag := fieldTag(f, dst); tag.Name != "" {
+			if !hasField(dst, tag.Name) {
+				return fmt.Errorf("%s: tag %q does not match any field of %v", f.Name, tag.Name, dst)
+			}
+		}
+	}
+	for i := 0; i < dst.NumField(); i++ {
+		f := dst.Field(i)
+		if tag := fieldTag(f, src); tag.Name != "" {
+			if !hasField(src, tag.Name) {
+				return fmt.Errorf("%s: tag %q does not match any field of %v", f.Name, tag.Name, src)
+			}
+		}
+	}
+
+	plan := getPlan(src, dst, &v.opts)
+	for _, f := range plan.fields {
+		srcField := src.FieldByIndex(f.srcIndex)
+		dstField := dst.FieldByIndex(f.dstIndex)
+		if err := v.validateElem(srcField.Type, dstField.Type); err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+	}
+	if v.opts.strict && len(plan.unmatched) > 0 {
+		return fmt.Errorf("%s: %w", plan.unmatched[0].Name, errUnmatched)
+	}
+	if len(plan.missing) > 0 {
+		return fmt.Errorf("%s: %w", plan.missing[0].Name, errUnmatched)
+	}
+	return nil
+}
+
+// validateElem checks a field or slice element pair
+func (v *mappingValidator) validateElem(src, dst reflect.Type) error {
+	if _, ok := v.opts.converters[typePair{src, dst}]; ok {
+		return nil
+	}
+	if _, ok := lookupConverter(src, dst); ok {
+		return nil
+	}
+	if isConvertible(src, dst) || isTimePair(src, dst) || v.opts.numbers(src, dst) || isTextPair(src, dst) {
+		return nil
+	}
+	// the dynamic value and the concrete type are resolved at runtime
+	if src.Kind() == reflect.Interface || dst.Kind() == reflect.Interface {
+		return nil
+	}
+
+	s, d := indirectType(src), indirectType(dst)
+	switch {
+	case s.Kind() == reflect.Struct && d.Kind() == reflect.Struct:
+		return v.validateStruct(s, d)
+	case isList(src.Kind()) && isList(dst.Kind()):
+		return v.validateElem(src.Elem(), dst.Elem())
+	case src.Kind() == reflect.Map && dst.Kind() == reflect.Map:
+		return v.validateMap(src, dst)
+	case s.Kind() == reflect.Struct && isStringKeyMap(d),
+		isStringKeyMap(s) && d.Kind() == reflect.Struct:
+		return nil
+	}
+
+	if v.strict {
+		return fmt.Errorf("cannot copy %v to %v", src, dst)
+	}
+	return nil
+}
+
+func (v *mappingValidator) validateMap(src, dst reflect.Type) error {
+	if err := v.validateElem(src.Key(), dst.Key()); err != nil {
+		return fmt.Errorf("key: %w", err)
+	}
+	return v.validateElem(src.Elem(), dst.Elem())
+}
+
+func isCopyableKind(k reflect.Kind) bool {
+	return k == reflect.Struct || isList(k) || k == reflect.Map
+}
+
+// isList determines whether the kind is a slice or an array
+func isList(k reflect.Kind) bool {
+	return k == reflect.Slice || k == reflect.Array
+}
+
+// isConvertible reports whether the conversion of src to dst always succeeds
+func isConvertible(src, dst reflect.Type) bool {
+	s, d := indirectType(src), indirectType(dst)
+	return convertible(src, dst) || convertible(s, d)
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// hasField determines whether the struct type has the field of the name or the path like Address.City
+func hasField(t reflect.Type, name string) bool {
+	if isPath(name) {
+		_, ok := fieldByPath(t, name)
+		return ok
+	}
+	_, ok := t.FieldByName(name)
+	return ok
+}
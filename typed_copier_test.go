@@ -0,0 +1,279 @@
+package xgo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/glassonion1/xgo"
+)
+
+type copierUser struct {
+	ID        string `copier:"Id"`
+	Name      string
+	Age       int32
+	CreatedAt time.Time
+	Address   *copierAddress
+	Tags      []string
+}
+
+type copierUserDTO struct {
+	Id        string
+	Name      string
+	Age       int64
+	CreatedAt int64
+	Address   copierAddressDTO
+	Tags      []string
+}
+
+type copierAddress struct {
+	City string
+}
+
+type copierAddressDTO struct {
+	City string
+}
+
+func TestCopier(t *testing.T) {
+	now := time.Now()
+	src := copierUser{
+		ID:        "xxxx",
+		Name:      "R2D2",
+		Age:       33,
+		CreatedAt: now,
+		Address:   &copierAddress{City: "Tokyo"},
+		Tags:      []string{"foo", "bar"},
+	}
+	want := copierUserDTO{
+		Id:        "xxxx",
+		Name:      "R2D2",
+		Age:       33,
+		CreatedAt: now.UnixNano(),
+		Address:   copierAddressDTO{City: "Tokyo"},
+		Tags:      []string{"foo", "bar"},
+	}
+
+	t.Run("struct to struct", func(t *testing.T) {
+		c, err := xgo.NewCopier[copierUser, copierUserDTO]()
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		got, err := c.Copy(src)
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("pointer to pointer", func(t *testing.T) {
+		c, err := xgo.NewCopier[*copierUser, *copierUserDTO]()
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		got, err := c.Copy(&src)
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(&want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+
+		got, err = c.Copy(nil)
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if got != nil {
+			t.Errorf("should be nil but: %v", got)
+		}
+	})
+
+	t.Run("slice to slice", func(t *testing.T) {
+		c, err := xgo.NewCopier[[]copierUser, []*copierUserDTO]()
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		got, err := c.Copy([]copierUser{src, src})
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff([]*copierUserDTO{&want, &want}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("map to map", func(t *testing.T) {
+		c, err := xgo.NewCopier[map[string]copierUser, map[string]copierUserDTO]()
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		got, err := c.Copy(map[string]copierUser{"foo": src})
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(map[string]copierUserDTO{"foo": want}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("with converter", func(t *testing.T) {
+		type IncompatibleField struct {
+			Id      string
+			Address string
+		}
+		c, err := xgo.NewCopier[copierUser, IncompatibleField](
+			xgo.WithConverter(func(a *copierAddress) (string, error) {
+				return a.City, nil
+			}),
+		)
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		got, err := c.Copy(src)
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(IncompatibleField{Id: "xxxx", Address: "Tokyo"}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+
+	t.Run("copy into", func(t *testing.T) {
+		c, err := xgo.NewCopier[copierUser, copierUserDTO]()
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		got := copierUserDTO{}
+		if err := c.CopyInto(src, &got); err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+		if err := c.CopyInto(src, nil); err == nil {
+			t.Error("should be error but not")
+		}
+	})
+
+	t.Run("interface fields", func(t *testing.T) {
+		type SI struct {
+			V    interface{}
+			Meta interface{}
+		}
+		type DI struct {
+			V    int
+			Meta interface{}
+		}
+		c, err := xgo.NewCopier[SI, DI]()
+		if err != nil {
+			t.Fatalf("should not be error but: %v", err)
+		}
+		got, err := c.Copy(SI{V: 1, Meta: []string{"foo"}})
+		if err != nil {
+			t.Errorf("should not be error but: %v", err)
+		}
+		if diff := cmp.Diff(DI{V: 1, Meta: []string{"foo"}}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s\n", diff)
+		}
+	})
+}
+
+func TestNewCopier_invalid(t *testing.T) {
+	type TagMismatch struct {
+		ID string `copier:"Identifier"`
+	}
+	type TagOptionMismatch struct {
+		ID string `copier:"Identifier,omitempty"`
+	}
+	type TagPathMismatch struct {
+		City string `copier:"Address.Town"`
+	}
+	type RequiredField struct {
+		Token string `copier:",required"`
+	}
+	type IncompatibleField struct {
+		Id      string
+		Address string
+	}
+
+	tests := []struct {
+		name string
+		new  func() error
+	}{
+		{
+			name: "map with int keys to struct",
+			new: func() error {
+				_, err := xgo.NewCopier[map[int]interface{}, copierUserDTO]()
+				return err
+			},
+		},
+		{
+			name: "value destination",
+			new: func() error {
+				_, err := xgo.NewCopier[copierUser, int]()
+				return err
+			},
+		},
+		{
+			name: "struct to slice",
+			new: func() error {
+				_, err := xgo.NewCopier[copierUser, []copierUserDTO]()
+				return err
+			},
+		},
+		{
+			name: "tag does not match",
+			new: func() error {
+				_, err := xgo.NewCopier[TagMismatch, copierUserDTO]()
+				return err
+			},
+		},
+		{
+			name: "tag with options does not match",
+			new: func() error {
+				_, err := xgo.NewCopier[TagOptionMismatch, copierUserDTO]()
+				return err
+			},
+		},
+		{
+			name: "tag path does not match",
+			new: func() error {
+				_, err := xgo.NewCopier[TagPathMismatch, copierUserDTO]()
+				return err
+			},
+		},
+		{
+			name: "required field",
+			new: func() error {
+				_, err := xgo.NewCopier[copierUser, RequiredField]()
+				return err
+			},
+		},
+		{
+			name: "incompatible field",
+			new: func() error {
+				_, err := xgo.NewCopier[copierUser, IncompatibleField]()
+				return err
+			},
+		},
+		{
+			name: "strict",
+			new: func() error {
+				_, err := xgo.NewCopier[copierAddress, copierUserDTO](xgo.Strict())
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.new(); err == nil {
+				t.Errorf("testing %s: should be error but not", tt.name)
+			}
+		})
+	}
+}
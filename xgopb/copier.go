@@ -9,9 +9,11 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// DeepCopy
-func DeepCopy(srcModel interface{}, dstModel interface{}) error {
-	return xgo.DeepCopyWithCustomSetter(srcModel, dstModel, setTimeField)
+// DeepCopy copies srcModel to dstModel with the conversions of the protobuf well-known types.
+// An int64 is copied from and to *timestamppb.Timestamp as the epoch unit of xgo.WithEpochUnit
+// or the copier tag like copier:",unixmilli", the default is nanoseconds.
+func DeepCopy(srcModel interface{}, dstModel interface{}, opts ...xgo.Option) error {
+	return xgo.DeepCopyWithCustomSetter(srcModel, dstModel, setTimeField, opts...)
 }
 
 func setTimeField(src, dst reflect.Value) (bool, error) {
@@ -55,6 +57,10 @@ func setTimeField(src, dst reflect.Value) (bool, error) {
 			return false, nil
 		}
 		if t.GetSeconds() <= 0 {
+			// the timestamp at or before the epoch leaves time.Time zero
+			if _, ok := dst.Interface().(time.Time); ok {
+				return true, nil
+			}
 			return false, nil
 		}
 		if err := t.CheckValid(); err != nil {
@@ -102,15 +108,8 @@ func setTimeField(src, dst reflect.Value) (bool, error) {
 		if t <= 0 {
 			return false, nil
 		}
-		// int64 -> time.Time or *timestamppb.Timestamp or *durationpb.Duration
+		// int64 -> *durationpb.Duration, xgo copies int64 to *timestamppb.Timestamp through time.Time
 		switch dst.Interface().(type) {
-		case *timestamppb.Timestamp:
-			ts := timestamppb.New(time.Unix(t, 0))
-			if err := ts.CheckValid(); err != nil {
-				return false, err
-			}
-			dst.Set(reflect.ValueOf(ts))
-			return true, nil
 		case *durationpb.Duration:
 			dst.Set(reflect.ValueOf(durationpb.New(time.Duration(t))))
 			return true, nil
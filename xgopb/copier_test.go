@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/glassonion1/xgo"
 	"github.com/glassonion1/xgo/xgopb"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -57,6 +58,10 @@ func TestDeepCopy_protobuf(t *testing.T) {
 		FinishedAt time.Time
 	}
 
+	type EpochField struct {
+		FinishedAt int64
+	}
+
 	type DurationPbField struct {
 		Duration *durationpb.Duration
 	}
@@ -223,6 +228,50 @@ func TestDeepCopy_protobuf(t *testing.T) {
 			want: &DurationPbField{},
 			err:  nil,
 		},
+		{
+			name: "int64 to timestampPb Field",
+			in: args{
+				src:  &EpochField{FinishedAt: 1748736000000000500},
+				dest: &TimestampField{},
+			},
+			want: &TimestampField{
+				FinishedAt: &timestamppb.Timestamp{Seconds: 1748736000, Nanos: 500},
+			},
+			err: nil,
+		},
+		{
+			name: "timestampPb Field to int64",
+			in: args{
+				src: &TimestampField{
+					FinishedAt: &timestamppb.Timestamp{Seconds: 1748736000, Nanos: 500},
+				},
+				dest: &EpochField{},
+			},
+			want: &EpochField{FinishedAt: 1748736000000000500},
+			err:  nil,
+		},
+		{
+			name: "pre-epoch timestampPb Field to time",
+			in: args{
+				src: &TimestampField{
+					FinishedAt: &timestamppb.Timestamp{Seconds: -1},
+				},
+				dest: &TimeField{},
+			},
+			want: &TimeField{},
+			err:  nil,
+		},
+		{
+			name: "timestampPb Field at the epoch to int64",
+			in: args{
+				src: &TimestampField{
+					FinishedAt: &timestamppb.Timestamp{Seconds: 0, Nanos: 5},
+				},
+				dest: &EpochField{},
+			},
+			want: &EpochField{},
+			err:  nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -248,3 +297,298 @@ func TestDeepCopy_protobuf(t *testing.T) {
 		})
 	}
 }
+
+func TestDeepCopy_nestedProtobuf(t *testing.T) {
+
+	type Item struct {
+		Name      string
+		CreatedAt time.Time
+		Timeout   time.Duration
+	}
+	type Profile struct {
+		UpdatedAt *time.Time
+	}
+	type Customer struct {
+		Profile Profile
+	}
+	type Order struct {
+		Customer *Customer
+		Items    []*Item
+		Tags     map[string]Item
+	}
+
+	type PbItem struct {
+		Name      string
+		CreatedAt *timestamppb.Timestamp
+		Timeout   *durationpb.Duration
+	}
+	type PbProfile struct {
+		UpdatedAt *timestamppb.Timestamp
+	}
+	type PbCustomer struct {
+		Profile *PbProfile
+	}
+	type PbOrder struct {
+		Customer *PbCustomer
+		Items    []*PbItem
+		Tags     map[string]*PbItem
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+	}
+
+	now := time.Unix(time.Now().Unix(), 0)
+	ts := &timestamppb.Timestamp{Seconds: now.Unix()}
+	d := &durationpb.Duration{Seconds: 300}
+
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+	}{
+		{
+			name: "model to pb",
+			in: args{
+				src: Order{
+					Customer: &Customer{Profile: Profile{UpdatedAt: &now}},
+					Items: []*Item{
+						{Name: "foo", CreatedAt: now, Timeout: 300 * time.Second},
+						{Name: "bar", CreatedAt: now},
+					},
+					Tags: map[string]Item{"baz": {Name: "baz", CreatedAt: now}},
+				},
+				dest: &PbOrder{},
+			},
+			want: &PbOrder{
+				Customer: &PbCustomer{Profile: &PbProfile{UpdatedAt: ts}},
+				Items: []*PbItem{
+					{Name: "foo", CreatedAt: ts, Timeout: d},
+					{Name: "bar", CreatedAt: ts},
+				},
+				Tags: map[string]*PbItem{"baz": {Name: "baz", CreatedAt: ts}},
+			},
+		},
+		{
+			name: "pb to model",
+			in: args{
+				src: PbOrder{
+					Customer: &PbCustomer{Profile: &PbProfile{UpdatedAt: ts}},
+					Items: []*PbItem{
+						{Name: "foo", CreatedAt: ts, Timeout: d},
+						{Name: "bar"},
+					},
+					Tags: map[string]*PbItem{"baz": {Name: "baz", CreatedAt: ts}},
+				},
+				dest: &Order{},
+			},
+			want: &Order{
+				Customer: &Customer{Profile: Profile{UpdatedAt: &now}},
+				Items: []*Item{
+					{Name: "foo", CreatedAt: now.UTC(), Timeout: 300 * time.Second},
+					{Name: "bar"},
+				},
+				Tags: map[string]Item{"baz": {Name: "baz", CreatedAt: now.UTC()}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgopb.DeepCopy(tt.in.src, tt.in.dest)
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			opt := cmpopts.IgnoreUnexported(timestamppb.Timestamp{},
+				durationpb.Duration{})
+			if diff := cmp.Diff(tt.want, tt.in.dest, opt); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopy_protobufList(t *testing.T) {
+
+	type Item struct {
+		Name      string
+		CreatedAt time.Time
+	}
+	type Schedule struct {
+		Slots [][]time.Time
+	}
+
+	type PbItem struct {
+		Name      string
+		CreatedAt *timestamppb.Timestamp
+	}
+	type PbSchedule struct {
+		Slots [][]*timestamppb.Timestamp
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+	}
+
+	now := time.Unix(time.Now().Unix(), 0)
+	ts := &timestamppb.Timestamp{Seconds: now.Unix()}
+	d := &durationpb.Duration{Seconds: 300}
+
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+	}{
+		{
+			name: "slice of models to slice of pb",
+			in: args{
+				src:  []Item{{Name: "foo", CreatedAt: now}, {Name: "bar", CreatedAt: now}},
+				dest: &[]*PbItem{},
+			},
+			want: &[]*PbItem{{Name: "foo", CreatedAt: ts}, {Name: "bar", CreatedAt: ts}},
+		},
+		{
+			name: "slice of pb to slice of models",
+			in: args{
+				src:  []*PbItem{{Name: "foo", CreatedAt: ts}, nil},
+				dest: &[]Item{},
+			},
+			want: &[]Item{{Name: "foo", CreatedAt: now.UTC()}, {}},
+		},
+		{
+			name: "slice of times to slice of timestamps",
+			in: args{
+				src:  []time.Time{now, now},
+				dest: &[]*timestamppb.Timestamp{},
+			},
+			want: &[]*timestamppb.Timestamp{ts, ts},
+		},
+		{
+			name: "slice of timestamps to slice of times",
+			in: args{
+				src:  []*timestamppb.Timestamp{ts},
+				dest: &[]time.Time{},
+			},
+			want: &[]time.Time{now.UTC()},
+		},
+		{
+			name: "slice of durations to slice of pb durations",
+			in: args{
+				src:  []time.Duration{300 * time.Second},
+				dest: &[]*durationpb.Duration{},
+			},
+			want: &[]*durationpb.Duration{d},
+		},
+		{
+			name: "slice of slices",
+			in: args{
+				src:  Schedule{Slots: [][]time.Time{{now}, {now, now}}},
+				dest: &PbSchedule{},
+			},
+			want: &PbSchedule{Slots: [][]*timestamppb.Timestamp{{ts}, {ts, ts}}},
+		},
+		{
+			name: "top-level slice of slices",
+			in: args{
+				src:  [][]time.Time{{now}, nil},
+				dest: &[][]*timestamppb.Timestamp{},
+			},
+			want: &[][]*timestamppb.Timestamp{{ts}, nil},
+		},
+		{
+			name: "map of slices",
+			in: args{
+				src:  map[string][]time.Time{"foo": {now}},
+				dest: &map[string][]*timestamppb.Timestamp{},
+			},
+			want: &map[string][]*timestamppb.Timestamp{"foo": {ts}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgopb.DeepCopy(tt.in.src, tt.in.dest)
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			opt := cmpopts.IgnoreUnexported(timestamppb.Timestamp{},
+				durationpb.Duration{})
+			if diff := cmp.Diff(tt.want, tt.in.dest, opt); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopy_epochUnits(t *testing.T) {
+	type Event struct {
+		StartAt int64
+		EndAt   int64 `copier:",unixmilli"`
+	}
+	type PbEvent struct {
+		StartAt *timestamppb.Timestamp
+		EndAt   *timestamppb.Timestamp
+	}
+
+	type args struct {
+		src  interface{}
+		dest interface{}
+		opts []xgo.Option
+	}
+
+	ts := &timestamppb.Timestamp{Seconds: 1748736000, Nanos: 500000000}
+
+	tests := []struct {
+		name string
+		in   args
+		want interface{}
+	}{
+		{
+			name: "nanoseconds by default",
+			in: args{
+				src:  Event{StartAt: 1748736000500000000, EndAt: 1748736000500},
+				dest: &PbEvent{},
+			},
+			want: &PbEvent{StartAt: ts, EndAt: ts},
+		},
+		{
+			name: "seconds option",
+			in: args{
+				src:  Event{StartAt: 1748736000, EndAt: 1748736000500},
+				dest: &PbEvent{},
+				opts: []xgo.Option{xgo.WithEpochUnit(xgo.UnixSeconds)},
+			},
+			want: &PbEvent{StartAt: &timestamppb.Timestamp{Seconds: 1748736000}, EndAt: ts},
+		},
+		{
+			name: "pb to epoch units",
+			in: args{
+				src:  PbEvent{StartAt: ts, EndAt: ts},
+				dest: &Event{},
+				opts: []xgo.Option{xgo.WithEpochUnit(xgo.UnixMicro)},
+			},
+			want: &Event{StartAt: 1748736000500000, EndAt: 1748736000500},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := xgopb.DeepCopy(tt.in.src, tt.in.dest, tt.in.opts...)
+			if err != nil {
+				t.Errorf("testing %s: should not be error for %#v but: %v", tt.name, tt.in, err)
+			}
+			opt := cmpopts.IgnoreUnexported(timestamppb.Timestamp{})
+			if diff := cmp.Diff(tt.want, tt.in.dest, opt); diff != "" {
+				t.Errorf("testing %s mismatch (-want +got):\n%s\n", tt.name, diff)
+			}
+		})
+	}
+}
@@ -7,3 +7,7 @@ require (
 	github.com/google/go-cmp v0.6.0
 	google.golang.org/protobuf v1.34.2
 )
+
+// the extension is tested with the xgo of the same tree, the consumers ignore the replace.
+// Bump the require above to the xgo release that has the APIs it uses before tagging xgopb, see README.md.
+replace github.com/glassonion1/xgo => ../